@@ -117,6 +117,25 @@ type FeatureGates struct {
 	// This will limit scheduling of the pods to Nodes with Linux.
 	DefaultNodeAffinity bool `json:"defaultNodeAffinity,omitempty"`
 
+	// DisableStatusUpdates turns all writes to the LokiStack status into no-ops.
+	// It is meant for deployments where the operator has no RBAC permissions on
+	// the status subresource. While enabled the LokiStack status conditions do not
+	// reflect the actual state of the deployment.
+	DisableStatusUpdates bool `json:"disableStatusUpdates,omitempty"`
+
+	// RequireSchemaChangeApproval keeps a LokiStack Pending instead of Ready while an upcoming
+	// storage schema change is not approved by setting the annotation
+	// `loki.grafana.com/schema-change-approved=true` on the LokiStack.
+	RequireSchemaChangeApproval bool `json:"requireSchemaChangeApproval,omitempty"`
+
+	// AuditConditionTransitions emits a structured audit log line for every actual
+	// transition of a LokiStack status condition.
+	AuditConditionTransitions bool `json:"auditConditionTransitions,omitempty"`
+
+	// PatchStatusUpdates writes only the changed fields of the LokiStack status as a
+	// JSON merge patch instead of updating the whole status.
+	PatchStatusUpdates bool `json:"patchStatusUpdates,omitempty"`
+
 	// OpenShift contains a set of feature gates supported only on OpenShift.
 	OpenShift OpenShiftFeatureGates `json:"openshift,omitempty"`
 
@@ -748,6 +748,34 @@ const (
 	// ConditionDegraded defines the condition that some or all components in the Loki deployment
 	// are degraded or the cluster cannot connect to object storage.
 	ConditionDegraded LokiStackConditionType = "Degraded"
+
+	// ConditionRecovering defines the condition that all components are ready again after the
+	// Loki deployment was degraded or failed, but not for long enough to be considered stable.
+	ConditionRecovering LokiStackConditionType = "Recovering"
+
+	// ConditionWarning defines the condition that the Loki deployment is operational
+	// but some components report an issue that needs attention.
+	ConditionWarning LokiStackConditionType = "Warning"
+
+	// ConditionReadOnly defines the condition that the Loki deployment is intentionally
+	// put in read-only mode, i.e. rejecting writes is expected.
+	ConditionReadOnly LokiStackConditionType = "ReadOnly"
+
+	// ConditionFlapping defines the condition that the Loki deployment conditions
+	// transition more frequently than expected, i.e. the deployment is unstable.
+	ConditionFlapping LokiStackConditionType = "Flapping"
+
+	// ConditionPartiallyAvailable defines the condition that some but not all pods of the Loki
+	// deployment are running, i.e. the deployment serves requests with reduced capacity.
+	ConditionPartiallyAvailable LokiStackConditionType = "PartiallyAvailable"
+
+	// ConditionUpgrading defines the condition that the Loki deployment is being upgraded
+	// from one Loki version to another, i.e. components of both versions may be running.
+	ConditionUpgrading LokiStackConditionType = "Upgrading"
+
+	// ConditionBlockedQueries defines the condition that a blocked queries policy is in effect
+	// for the Loki deployment, i.e. rejecting queries matching the blocked patterns is expected.
+	ConditionBlockedQueries LokiStackConditionType = "BlockedQueries"
 )
 
 // LokiStackConditionReason defines the type for valid reasons of a Loki deployment conditions.
@@ -758,6 +786,12 @@ const (
 	ReasonFailedComponents LokiStackConditionReason = "FailedComponents"
 	// ReasonPendingComponents when all/some LokiStack components pending dependencies
 	ReasonPendingComponents LokiStackConditionReason = "PendingComponents"
+	// ReasonPendingDependency when a custom resource definition or admission webhook required by the LokiStack is not installed yet.
+	ReasonPendingDependency LokiStackConditionReason = "PendingDependency"
+	// ReasonPendingSchemaChangeApproval when an upcoming storage schema change awaits the approval of an administrator.
+	ReasonPendingSchemaChangeApproval LokiStackConditionReason = "PendingSchemaChangeApproval"
+	// ReasonRecoveringComponents when all LokiStack components are ready again but still within the recovery window.
+	ReasonRecoveringComponents LokiStackConditionReason = "RecoveringComponents"
 	// ReasonReadyComponents when all LokiStack components are ready to serve traffic.
 	ReasonReadyComponents LokiStackConditionReason = "ReadyComponents"
 	// ReasonMissingObjectStorageSecret when the required secret to store logs to object
@@ -765,6 +799,20 @@ const (
 	ReasonMissingObjectStorageSecret LokiStackConditionReason = "MissingObjectStorageSecret"
 	// ReasonInvalidObjectStorageSecret when the format of the secret is invalid.
 	ReasonInvalidObjectStorageSecret LokiStackConditionReason = "InvalidObjectStorageSecret"
+	// ReasonInconsistentObjectStorageEndpoint when the scheme of the object storage endpoint contradicts the insecure flag of the secret.
+	ReasonInconsistentObjectStorageEndpoint LokiStackConditionReason = "InconsistentObjectStorageEndpoint"
+	// ReasonObjectStorageCanaryFailed when writing and reading back an object from the object storage fails.
+	ReasonObjectStorageCanaryFailed LokiStackConditionReason = "ObjectStorageCanaryFailed"
+	// ReasonClockSkew when the clocks of the LokiStack components drift apart.
+	ReasonClockSkew LokiStackConditionReason = "ClockSkew"
+	// ReasonCacheUnreachable when the chunks or results cache cannot be reached.
+	ReasonCacheUnreachable LokiStackConditionReason = "CacheUnreachable"
+	// ReasonAutoscalingAtMaxReplicas when the horizontal pod autoscaler of a component is pinned at its maximum replicas.
+	ReasonAutoscalingAtMaxReplicas LokiStackConditionReason = "AutoscalingAtMaxReplicas"
+	// ReasonMismatchedObjectStorageSecret when the secret lacks keys required by the configured object storage type.
+	ReasonMismatchedObjectStorageSecret LokiStackConditionReason = "MismatchedObjectStorageSecret"
+	// ReasonMissingStorageClass when the StorageClass assigned to the LokiStack volumes does not exist.
+	ReasonMissingStorageClass LokiStackConditionReason = "MissingStorageClass"
 	// ReasonInvalidObjectStorageSchema when the spec contains an invalid schema(s).
 	ReasonInvalidObjectStorageSchema LokiStackConditionReason = "InvalidObjectStorageSchema"
 	// ReasonMissingObjectStorageCAConfigMap when the required configmap to verify object storage
@@ -775,8 +823,12 @@ const (
 	// ReasonMissingRulerSecret when the required secret to authorization remote write connections
 	// for the ruler is missing.
 	ReasonMissingRulerSecret LokiStackConditionReason = "MissingRulerSecret"
+	// ReasonInvalidRuleExpression when an alerting or recording rule selected by the LokiStack contains an invalid LogQL expression.
+	ReasonInvalidRuleExpression LokiStackConditionReason = "InvalidRuleExpression"
 	// ReasonInvalidRulerSecret when the format of the ruler remote write authorization secret is invalid.
 	ReasonInvalidRulerSecret LokiStackConditionReason = "InvalidRulerSecret"
+	// ReasonInvalidRulerExternalLabels when the alertmanager external labels of the ruler config are not valid label names or values.
+	ReasonInvalidRulerExternalLabels LokiStackConditionReason = "InvalidRulerExternalLabels"
 	// ReasonInvalidReplicationConfiguration when the configurated replication factor is not valid
 	// with the select cluster size.
 	ReasonInvalidReplicationConfiguration LokiStackConditionReason = "InvalidReplicationConfiguration"
@@ -785,12 +837,66 @@ const (
 	ReasonMissingGatewayTenantSecret LokiStackConditionReason = "MissingGatewayTenantSecret"
 	// ReasonInvalidGatewayTenantSecret when the format of the secret is invalid.
 	ReasonInvalidGatewayTenantSecret LokiStackConditionReason = "InvalidGatewayTenantSecret"
+	// ReasonInvalidLimitsConfiguration when the global or per-tenant limits contain impossible values.
+	ReasonInvalidLimitsConfiguration LokiStackConditionReason = "InvalidLimitsConfiguration"
+	// ReasonConflictingIngestionLimits when the ingestion rate limits of the LokiStack contradict each other.
+	ReasonConflictingIngestionLimits LokiStackConditionReason = "ConflictingIngestionLimits"
+	// ReasonInvalidRetentionStreamSelector when a per-stream retention selector is not a valid LogQL stream selector.
+	ReasonInvalidRetentionStreamSelector LokiStackConditionReason = "InvalidRetentionStreamSelector"
 	// ReasonInvalidTenantsConfiguration when the tenant configuration provided is invalid.
 	ReasonInvalidTenantsConfiguration LokiStackConditionReason = "InvalidTenantsConfiguration"
+	// ReasonConflictingTenantsModes when the tenant configuration combines settings of mutually exclusive modes.
+	ReasonConflictingTenantsModes LokiStackConditionReason = "ConflictingTenantsModes"
 	// ReasonMissingGatewayOpenShiftBaseDomain when the reconciler cannot lookup the OpenShift DNS base domain.
 	ReasonMissingGatewayOpenShiftBaseDomain LokiStackConditionReason = "MissingGatewayOpenShiftBaseDomain"
+	// ReasonInvalidTLSProfile when the TLS security profile specifies unsupported cipher suites or an invalid minimum TLS version.
+	ReasonInvalidTLSProfile LokiStackConditionReason = "InvalidTLSProfile"
 	// ReasonFailedCertificateRotation when the reconciler cannot rotate any of the required TLS certificates.
 	ReasonFailedCertificateRotation LokiStackConditionReason = "FailedCertificateRotation"
+	// ReasonCertificateExpiring when a LokiStack certificate expires within the configured warning window.
+	ReasonCertificateExpiring LokiStackConditionReason = "CertificateExpiring"
+	// ReasonCertificateExpired when a LokiStack certificate is expired.
+	ReasonCertificateExpired LokiStackConditionReason = "CertificateExpired"
+	// ReasonCARotationInProgress when some components do not trust the new signing CA yet.
+	ReasonCARotationInProgress LokiStackConditionReason = "CARotationInProgress"
+	// ReasonCARotationBlocked when the signing CA rotation cannot complete because some components do not trust the new CA.
+	ReasonCARotationBlocked LokiStackConditionReason = "CARotationBlocked"
+	// ReasonInsufficientClusterCapacity when the requested size cannot be scheduled on the allocatable capacity of the cluster nodes.
+	ReasonInsufficientClusterCapacity LokiStackConditionReason = "InsufficientClusterCapacity"
+	// ReasonInsufficientVolumeSize when provisioned persistent volume claims are smaller than required by the LokiStack size.
+	ReasonInsufficientVolumeSize LokiStackConditionReason = "InsufficientVolumeSize"
+	// ReasonUnschedulableAffinity when component pods cannot be scheduled because of their node selector or (anti-)affinity constraints.
+	ReasonUnschedulableAffinity LokiStackConditionReason = "UnschedulableAffinity"
+	// ReasonRolloutBlockedByPodDisruptionBudget when the rollout of a component is stuck on a PodDisruptionBudget allowing no disruptions.
+	ReasonRolloutBlockedByPodDisruptionBudget LokiStackConditionReason = "RolloutBlockedByPodDisruptionBudget"
+	// ReasonUpgradeInProgress when the LokiStack components are rolled out with a new Loki version.
+	ReasonUpgradeInProgress LokiStackConditionReason = "UpgradeInProgress"
+	// ReasonReadOnlyMode when the LokiStack is intentionally put in read-only mode.
+	ReasonReadOnlyMode LokiStackConditionReason = "ReadOnlyMode"
+	// ReasonBlockedQueriesPolicy when queries matching the configured blocked patterns are rejected.
+	ReasonBlockedQueriesPolicy LokiStackConditionReason = "BlockedQueriesPolicy"
+	// ReasonGatewayRouteNotAdmitted when the external route or ingress of the gateway is not admitted.
+	ReasonGatewayRouteNotAdmitted LokiStackConditionReason = "GatewayRouteNotAdmitted"
+	// ReasonMissingResource when the reconciler cannot find a resource required by the LokiStack.
+	ReasonMissingResource LokiStackConditionReason = "MissingResource"
+	// ReasonForbiddenAccess when the reconciler is not permitted to access a resource required by the LokiStack.
+	ReasonForbiddenAccess LokiStackConditionReason = "ForbiddenAccess"
+	// ReasonReconcileFailed when the reconciler fails for an error not classified otherwise.
+	ReasonReconcileFailed LokiStackConditionReason = "ReconcileFailed"
+	// ReasonConditionsFlapping when the LokiStack conditions transition more often than the configured threshold.
+	ReasonConditionsFlapping LokiStackConditionReason = "ConditionsFlapping"
+	// ReasonMultipleIssues when more Degraded and Failed conditions are active on the LokiStack and its tenants than the configured threshold.
+	ReasonMultipleIssues LokiStackConditionReason = "MultipleIssues"
+	// ReasonMultipleWarnings when more than one warning is active on the LokiStack.
+	ReasonMultipleWarnings LokiStackConditionReason = "MultipleWarnings"
+	// ReasonPartialAvailability when some but not all pods of the LokiStack components are running.
+	ReasonPartialAvailability LokiStackConditionReason = "PartialAvailability"
+	// ReasonQueryQueueSaturated when the query scheduler queue of the LokiStack is saturated, i.e. queries are likely to time out.
+	ReasonQueryQueueSaturated LokiStackConditionReason = "QueryQueueSaturated"
+	// ReasonWALDiskPressure when the disk of the write ahead log of a component is close to or at capacity.
+	ReasonWALDiskPressure LokiStackConditionReason = "WALDiskPressure"
+	// ReasonConflictingController when the LokiStack churns as if another controller manages it too.
+	ReasonConflictingController LokiStackConditionReason = "ConflictingController"
 )
 
 // PodStatusMap defines the type for mapping pod status to pod name.
@@ -889,6 +995,88 @@ type LokiStackStatus struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:io.kubernetes.conditions"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Tenants provides the health conditions per tenant of the LokiStack
+	// keyed by the tenant name.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Tenants map[string]LokiStackTenantStatus `json:"tenants,omitempty"`
+
+	// OperatorVersion is the version of the operator that wrote the current conditions.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// ConditionsHash is a stable hash of the type, status, reason and message of the
+	// conditions. External tools can compare it to detect actual status changes.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	ConditionsHash string `json:"conditionsHash,omitempty"`
+
+	// Message is the message of the active phase condition for consumers reading
+	// a single message instead of walking the conditions.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// RunbookURL links the remediation runbook of the active Degraded condition reason.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	RunbookURL string `json:"runbookURL,omitempty"`
+
+	// ConditionMessageKeys holds the stable message keys of the active conditions
+	// keyed by the condition type, e.g. to translate the condition messages.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	ConditionMessageKeys map[string]LokiStackConditionMessageKey `json:"conditionMessageKeys,omitempty"`
+
+	// Warnings lists the active warnings of the LokiStack summarized by the condition Warning.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Warnings []LokiStackWarning `json:"warnings,omitempty"`
+}
+
+// LokiStackConditionMessageKey defines the stable key and parameters of a condition message.
+type LokiStackConditionMessageKey struct {
+	// Key identifies the condition message independent of its wording.
+	Key string `json:"key"`
+
+	// Params are the values referenced by the condition message.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// LokiStackWarning defines a single active warning of a LokiStack.
+type LokiStackWarning struct {
+	// Reason is the reason of the warning.
+	Reason LokiStackConditionReason `json:"reason"`
+
+	// Component is the LokiStack component the warning is limited to.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Component string `json:"component,omitempty"`
+
+	// Message is the human readable message of the warning.
+	Message string `json:"message"`
+}
+
+// LokiStackTenantStatus defines the observed state of a single LokiStack tenant.
+type LokiStackTenantStatus struct {
+	// Conditions of the tenant health.
+	//
+	// +optional
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
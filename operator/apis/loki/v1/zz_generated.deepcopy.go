@@ -343,6 +343,28 @@ func (in *LokiStackComponentStatus) DeepCopy() *LokiStackComponentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiStackConditionMessageKey) DeepCopyInto(out *LokiStackConditionMessageKey) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackConditionMessageKey.
+func (in *LokiStackConditionMessageKey) DeepCopy() *LokiStackConditionMessageKey {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiStackConditionMessageKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LokiStackList) DeepCopyInto(out *LokiStackList) {
 	*out = *in
@@ -428,6 +450,25 @@ func (in *LokiStackStatus) DeepCopyInto(out *LokiStackStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make(map[string]LokiStackTenantStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ConditionMessageKeys != nil {
+		in, out := &in.ConditionMessageKeys, &out.ConditionMessageKeys
+		*out = make(map[string]LokiStackConditionMessageKey, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]LokiStackWarning, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackStatus.
@@ -460,6 +501,43 @@ func (in *LokiStackStorageStatus) DeepCopy() *LokiStackStorageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiStackTenantStatus) DeepCopyInto(out *LokiStackTenantStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackTenantStatus.
+func (in *LokiStackTenantStatus) DeepCopy() *LokiStackTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiStackTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LokiStackWarning) DeepCopyInto(out *LokiStackWarning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LokiStackWarning.
+func (in *LokiStackWarning) DeepCopy() *LokiStackWarning {
+	if in == nil {
+		return nil
+	}
+	out := new(LokiStackWarning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LokiTemplateSpec) DeepCopyInto(out *LokiTemplateSpec) {
 	*out = *in
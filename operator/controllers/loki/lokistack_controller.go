@@ -117,6 +117,7 @@ type LokiStackReconciler struct {
 // +kubebuilder:rbac:groups=loki.grafana.com,resources=lokistacks/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods;nodes;services;endpoints;configmaps;secrets;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings;clusterroles;roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;delete
@@ -124,8 +125,10 @@ type LokiStackReconciler struct {
 // +kubebuilder:rbac:urls=/api/v2/alerts,verbs=create
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=dnses;apiservers;proxies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -141,41 +144,71 @@ func (r *LokiStackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 	if !ok {
+		// Deleted LokiStacks are not managed either, thus drop their state kept across reconciliations
+		status.Forget(req.NamespacedName)
+
 		r.Log.Info("Skipping reconciliation for unmanaged lokistack resource", "name", req.NamespacedName)
 		// Stop requeueing for unmanaged LokiStack custom resources
 		return ctrl.Result{}, nil
 	}
 
+	owned, err := status.IsOwned(ctx, r.Client, req)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !owned {
+		r.Log.Info("Skipping reconciliation for lokistack resource owned by another operator", "name", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	conflicting, err := status.DetectConflictingController(ctx, r.Client, req)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if conflicting {
+		r.Log.Info("Skipping reconciliation for lokistack resource with possible conflicting controller", "name", req.NamespacedName)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	if r.FeatureGates.BuiltInCertManagement.Enabled {
 		err = handlers.CreateOrRotateCertificates(ctx, r.Log, req, r.Client, r.Scheme, r.FeatureGates)
-		if res, derr := handleDegradedError(ctx, r.Client, req, err); derr != nil {
-			return res, derr
+		if err != nil {
+			return handleDegradedError(ctx, r.Client, req, err)
 		}
 	}
 
 	err = handlers.CreateOrUpdateLokiStack(ctx, r.Log, req, r.Client, r.Scheme, r.FeatureGates)
-	if res, derr := handleDegradedError(ctx, r.Client, req, err); derr != nil {
-		return res, derr
+	if err != nil {
+		return handleDegradedError(ctx, r.Client, req, err)
 	}
+	status.ResetDegradedErrors(req)
 
 	err = status.Refresh(ctx, r.Client, req)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return status.RequeueWhileRecovering(ctx, r.Client, req)
 }
 
+// handleDegradedError sets the condition for a degraded error or returns any other error as is.
+// Callers must return its result right away, since refreshing the status afterwards would
+// overwrite the condition just set.
 func handleDegradedError(ctx context.Context, c client.Client, req ctrl.Request, err error) (ctrl.Result, error) {
 	var degraded *status.DegradedError
-	if errors.As(err, &degraded) {
-		err = status.SetDegradedCondition(ctx, c, req, degraded.Message, degraded.Reason)
+	if forbidden, ok := status.ClassifyForbidden(err); ok {
+		// Missing RBAC degrades the stack instead of failing the reconciliation
+		degraded = forbidden
+	}
+	if degraded != nil || errors.As(err, &degraded) {
+		set, err := status.RecordDegradedError(ctx, c, req, degraded)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
+		// Requeue below the degraded error threshold to tell transient errors apart
 		return ctrl.Result{
-			Requeue: degraded.Requeue,
+			Requeue: degraded.Requeue || !set,
 		}, nil
 	}
 
@@ -188,10 +221,40 @@ func handleDegradedError(ctx context.Context, c client.Client, req ctrl.Request,
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LokiStackReconciler) SetupWithManager(mgr manager.Manager) error {
+	if err := mgr.Add(manager.RunnableFunc(r.markUnknownOnStartup)); err != nil {
+		return err
+	}
+
 	b := ctrl.NewControllerManagedBy(mgr)
 	return r.buildController(k8s.NewCtrlBuilder(b))
 }
 
+// markUnknownOnStartup marks the state conditions of all LokiStacks as Unknown once the manager
+// starts, since they reflect the previous operator run until reconciled. Failures are only logged
+// to keep the manager running, the next reconcile writes the actual conditions anyway.
+func (r *LokiStackReconciler) markUnknownOnStartup(ctx context.Context) error {
+	lokiStacks := &lokiv1.LokiStackList{}
+	if err := r.Client.List(ctx, lokiStacks); err != nil {
+		r.Log.Error(err, "Error getting LokiStack resources on startup")
+		return nil
+	}
+
+	for _, stack := range lokiStacks.Items {
+		req := ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: stack.Namespace,
+				Name:      stack.Name,
+			},
+		}
+
+		if err := status.MarkUnknownOnStartup(ctx, r.Client, req); err != nil {
+			r.Log.Error(err, "Error marking LokiStack conditions unknown on startup", "name", req.NamespacedName)
+		}
+	}
+
+	return nil
+}
+
 func (r *LokiStackReconciler) buildController(bld k8s.Builder) error {
 	bld = bld.
 		For(&lokiv1.LokiStack{}, createOrUpdateOnlyPred).
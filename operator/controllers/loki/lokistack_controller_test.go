@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"flag"
 	"io"
 	"os"
@@ -11,6 +12,7 @@ import (
 	configv1 "github.com/grafana/loki/operator/apis/config/v1"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/status"
 	openshiftconfigv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/require"
@@ -18,9 +20,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -249,3 +256,58 @@ func TestLokiStackController_RegisterWatchedResources(t *testing.T) {
 		require.Equal(t, tst.pred, opts[0])
 	}
 }
+
+func TestLokiStackController_Reconcile_WhenDegradedError_KeepDegraded(t *testing.T) {
+	stack := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: lokiv1.LokiStackSpec{
+			ManagementState: lokiv1.ManagementStateManaged,
+			Size:            lokiv1.SizeOneXExtraSmall,
+			Storage: lokiv1.ObjectStorageSpec{
+				Secret: lokiv1.ObjectStorageSecretSpec{
+					Name: "missing-secret",
+					Type: lokiv1.ObjectStorageSecretS3,
+				},
+			},
+		},
+	}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k := &k8sfakes.FakeClient{}
+	sw := &k8sfakes.FakeStatusWriter{}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+		if name == req.NamespacedName {
+			k.SetClientObject(obj, stack.DeepCopy())
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, name.Name)
+	}
+	k.StatusStub = func() client.StatusWriter { return sw }
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&stack)
+		return nil
+	}
+	k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&stack)
+		return nil
+	}
+
+	c := &LokiStackReconciler{Client: k, Log: logger, Scheme: scheme}
+	res, err := c.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	// The status refresh does not overwrite the condition set for the degraded error
+	degraded := status.ConditionsMap(&stack)[string(lokiv1.ConditionDegraded)]
+	require.Equal(t, metav1.ConditionTrue, degraded.Status)
+	require.Equal(t, string(lokiv1.ReasonMissingObjectStorageSecret), degraded.Reason)
+}
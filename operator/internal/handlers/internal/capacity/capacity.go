@@ -0,0 +1,119 @@
+package capacity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/grafana/loki/operator/internal/status"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// checkedResources are the resources compared against the cluster capacity.
+var checkedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// ValidateClusterCapacity compares the resource requests of the LokiStack size against the
+// allocatable capacity of the schedulable cluster nodes. It returns a degraded error if the
+// size is clearly infeasible, i.e. a single component pod requests more than the largest node
+// allocates or all pods together request more than all nodes allocate.
+//
+// The check uses the node allocatable capacity and not the current usage, thus a busy or
+// bursty cluster never degrades the LokiStack. It is skipped if the nodes cannot be listed.
+func ValidateClusterCapacity(ctx context.Context, k k8s.Client, stack lokiv1.LokiStackSpec) error {
+	var nodes corev1.NodeList
+	if err := k.List(ctx, &nodes); err != nil {
+		if apierrors.IsForbidden(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to list cluster nodes")
+	}
+
+	var (
+		largest = corev1.ResourceList{}
+		total   = corev1.ResourceList{}
+	)
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+
+		for _, name := range checkedResources {
+			q, ok := node.Status.Allocatable[name]
+			if !ok {
+				continue
+			}
+			if l, ok := largest[name]; !ok || q.Cmp(l) > 0 {
+				largest[name] = q.DeepCopy()
+			}
+			addTo(total, name, q, 1)
+		}
+	}
+
+	if len(total) == 0 {
+		// No capacity information available
+		return nil
+	}
+
+	components, err := manifests.StackRequests(stack)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to lookup lokistack size requests", "size", stack.Size)
+	}
+
+	requested := corev1.ResourceList{}
+	for _, c := range components {
+		for _, name := range checkedResources {
+			q, ok := c.Requests[name]
+			if !ok || c.Replicas == 0 {
+				continue
+			}
+
+			if l, ok := largest[name]; ok && q.Cmp(l) > 0 {
+				return insufficientCapacityError(stack.Size, fmt.Sprintf(
+					"component %s requests %s %s per pod but the largest node allocates %s",
+					c.Name, q.String(), name, l.String(),
+				))
+			}
+			addTo(requested, name, q, int64(c.Replicas))
+		}
+	}
+
+	for _, name := range checkedResources {
+		r, ok := requested[name]
+		if !ok {
+			continue
+		}
+
+		if t, ok := total[name]; ok && r.Cmp(t) > 0 {
+			return insufficientCapacityError(stack.Size, fmt.Sprintf(
+				"components request %s %s in total but the schedulable nodes allocate %s",
+				r.String(), name, t.String(),
+			))
+		}
+	}
+
+	return nil
+}
+
+func addTo(list corev1.ResourceList, name corev1.ResourceName, q resource.Quantity, times int64) {
+	sum := list[name]
+	for i := int64(0); i < times; i++ {
+		sum.Add(q)
+	}
+	list[name] = sum
+}
+
+func insufficientCapacityError(size lokiv1.LokiStackSizeType, detail string) error {
+	return &status.DegradedError{
+		Message:     fmt.Sprintf("Insufficient cluster capacity for LokiStack size %s: %s", size, detail),
+		Reason:      lokiv1.ReasonInsufficientClusterCapacity,
+		Requeue:     true,
+		Remediation: "Add nodes with more allocatable capacity or choose a smaller LokiStack size",
+	}
+}
@@ -0,0 +1,99 @@
+package capacity_test
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/handlers/internal/capacity"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func node(cpu, memory string, unschedulable bool) corev1.Node {
+	return corev1.Node{
+		Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func TestValidateClusterCapacity(t *testing.T) {
+	table := []struct {
+		name     string
+		size     lokiv1.LokiStackSizeType
+		nodes    []corev1.Node
+		wantDegr bool
+		wantMsg  string
+	}{
+		{
+			name: "no nodes listed",
+			size: lokiv1.SizeOneXMedium,
+		},
+		{
+			name:  "sufficient capacity",
+			size:  lokiv1.SizeOneXExtraSmall,
+			nodes: []corev1.Node{node("8", "32Gi", false), node("8", "32Gi", false)},
+		},
+		{
+			name:     "pod exceeds largest node",
+			size:     lokiv1.SizeOneXMedium,
+			nodes:    []corev1.Node{node("4", "64Gi", false)},
+			wantDegr: true,
+			wantMsg:  "Insufficient cluster capacity for LokiStack size 1x.medium: component ingester requests 6 cpu per pod but the largest node allocates 4",
+		},
+		{
+			name:     "total requests exceed schedulable nodes",
+			size:     lokiv1.SizeOneXExtraSmall,
+			nodes:    []corev1.Node{node("2", "8Gi", false), node("64", "256Gi", true)},
+			wantDegr: true,
+			wantMsg:  "Insufficient cluster capacity for LokiStack size 1x.extra-small: components request 4700m cpu in total but the schedulable nodes allocate 2",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			k := &k8sfakes.FakeClient{}
+			k.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				k.SetClientObjectList(list, &corev1.NodeList{Items: tc.nodes})
+				return nil
+			}
+
+			err := capacity.ValidateClusterCapacity(context.Background(), k, lokiv1.LokiStackSpec{Size: tc.size})
+			if !tc.wantDegr {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInsufficientClusterCapacity, degraded.Reason)
+			require.Equal(t, tc.wantMsg, degraded.Message)
+			require.True(t, degraded.Requeue)
+		})
+	}
+}
+
+func TestValidateClusterCapacity_WhenListForbidden_Skip(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	k.ListStub = func(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+		return apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", nil)
+	}
+
+	err := capacity.ValidateClusterCapacity(context.Background(), k, lokiv1.LokiStackSpec{Size: lokiv1.SizeOneXMedium})
+	require.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+package capacity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/grafana/loki/operator/internal/status"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// componentLabel is the label holding the component of LokiStack objects.
+const componentLabel = "app.kubernetes.io/component"
+
+// ValidateVolumeSizes compares the persistent volume claims of the LokiStack component statefulsets
+// against the volume sizes of the LokiStack size, e.g. claims kept from a smaller size after
+// changing the size. The provisioned capacity of a bound claim is compared, otherwise its requested
+// size. Claims of at least the required size are valid. It returns a degraded error naming all
+// undersized claims with their size and the required size. It is skipped if the claims cannot
+// be listed.
+func ValidateVolumeSizes(ctx context.Context, k k8s.Client, stack *lokiv1.LokiStack) error {
+	required, err := manifests.StackVolumeSizes(stack.Spec.Size)
+	if err != nil {
+		return kverrors.Wrap(err, "failed to lookup lokistack size volumes", "size", stack.Spec.Size)
+	}
+
+	var pvcs corev1.PersistentVolumeClaimList
+	opts := []client.ListOption{
+		client.MatchingLabels(manifests.StackLabels(stack.Name)),
+		client.InNamespace(stack.Namespace),
+	}
+	if err := k.List(ctx, &pvcs, opts...); err != nil {
+		if apierrors.IsForbidden(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to list LokiStack persistent volume claims", "name", stack.Name)
+	}
+
+	var undersized []string
+	for _, pvc := range pvcs.Items {
+		// Claims of statefulsets are named <template>-<statefulset>-<ordinal>
+		volume, _, _ := strings.Cut(pvc.Name, "-")
+
+		want, ok := required[pvc.Labels[componentLabel]][volume]
+		if !ok {
+			continue
+		}
+
+		got, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok {
+			got, ok = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		}
+		if !ok || got.Cmp(want) >= 0 {
+			continue
+		}
+
+		undersized = append(undersized, fmt.Sprintf("%s (%s < %s)", pvc.Name, got.String(), want.String()))
+	}
+
+	if len(undersized) == 0 {
+		return nil
+	}
+	sort.Strings(undersized)
+
+	return &status.DegradedError{
+		Message:     fmt.Sprintf("Persistent volume claims smaller than required by LokiStack size %s: %s", stack.Spec.Size, strings.Join(undersized, ", ")),
+		Reason:      lokiv1.ReasonInsufficientVolumeSize,
+		Requeue:     true,
+		Remediation: "Expand the persistent volume claims or recreate them with the required size",
+	}
+}
@@ -0,0 +1,121 @@
+package capacity_test
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/handlers/internal/capacity"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func pvc(name, component, capacity string) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "some-ns",
+			Labels:    map[string]string{"app.kubernetes.io/component": component},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+		},
+	}
+}
+
+func TestValidateVolumeSizes(t *testing.T) {
+	// The size 1x.small requires 10Gi for the ingester storage and 150Gi for the write ahead log
+	table := []struct {
+		name    string
+		pvcs    []corev1.PersistentVolumeClaim
+		wantMsg string
+	}{
+		{
+			name: "no claims",
+		},
+		{
+			name: "exactly met",
+			pvcs: []corev1.PersistentVolumeClaim{
+				pvc("storage-my-stack-ingester-0", "ingester", "10Gi"),
+				pvc("wal-my-stack-ingester-0", "ingester", "150Gi"),
+			},
+		},
+		{
+			name: "oversized",
+			pvcs: []corev1.PersistentVolumeClaim{
+				pvc("storage-my-stack-ingester-0", "ingester", "20Gi"),
+				pvc("wal-my-stack-ingester-0", "ingester", "200Gi"),
+				pvc("storage-my-stack-compactor-0", "compactor", "1Ti"),
+			},
+		},
+		{
+			name: "undersized",
+			pvcs: []corev1.PersistentVolumeClaim{
+				pvc("storage-my-stack-ingester-0", "ingester", "10Gi"),
+				pvc("wal-my-stack-ingester-0", "ingester", "10Gi"),
+				pvc("wal-my-stack-ingester-1", "ingester", "150Gi"),
+				pvc("storage-my-stack-index-gateway-0", "index-gateway", "5Gi"),
+			},
+			wantMsg: "Persistent volume claims smaller than required by LokiStack size 1x.small: " +
+				"storage-my-stack-index-gateway-0 (5Gi < 50Gi), wal-my-stack-ingester-0 (10Gi < 150Gi)",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			k := &k8sfakes.FakeClient{}
+			k.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				k.SetClientObjectList(list, &corev1.PersistentVolumeClaimList{Items: tc.pvcs})
+				return nil
+			}
+
+			stack := &lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-stack", Namespace: "some-ns"},
+				Spec:       lokiv1.LokiStackSpec{Size: lokiv1.SizeOneXSmall},
+			}
+
+			err := capacity.ValidateVolumeSizes(context.Background(), k, stack)
+			if tc.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInsufficientVolumeSize, degraded.Reason)
+			require.Equal(t, tc.wantMsg, degraded.Message)
+			require.True(t, degraded.Requeue)
+		})
+	}
+}
+
+func TestValidateVolumeSizes_WhenListForbidden_Skip(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	k.ListStub = func(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+		return apierrors.NewForbidden(schema.GroupResource{Resource: "persistentvolumeclaims"}, "", nil)
+	}
+
+	stack := &lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-stack", Namespace: "some-ns"},
+		Spec:       lokiv1.LokiStackSpec{Size: lokiv1.SizeOneXSmall},
+	}
+
+	err := capacity.ValidateVolumeSizes(context.Background(), k, stack)
+	require.NoError(t, err)
+}
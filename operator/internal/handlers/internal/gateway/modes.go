@@ -1,10 +1,56 @@
 package gateway
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ViaQ/logerr/v2/kverrors"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
 )
 
+// ValidateModeConflicts detects tenants configurations combining the settings of mutually
+// exclusive modes, e.g. static roles copied into a spec using the openshift-logging mode.
+// Static roles and role bindings belong to the static mode and an OPA endpoint belongs to
+// the dynamic mode. It returns a degraded error naming all conflicting modes.
+func ValidateModeConflicts(tenants *lokiv1.TenantsSpec) error {
+	modes := []string{string(tenants.Mode)}
+	for _, m := range configuredModes(tenants) {
+		if m != tenants.Mode {
+			modes = append(modes, string(m))
+		}
+	}
+
+	if len(modes) == 1 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message:     fmt.Sprintf("Conflicting tenants modes configured together: %s", strings.Join(modes, ", ")),
+		Reason:      lokiv1.ReasonConflictingTenantsModes,
+		Requeue:     true,
+		Remediation: "Pick one of these modes and remove the settings of the others",
+	}
+}
+
+// configuredModes returns the modes whose exclusive settings are set in the tenants configuration.
+func configuredModes(tenants *lokiv1.TenantsSpec) []lokiv1.ModeType {
+	authz := tenants.Authorization
+	if authz == nil {
+		return nil
+	}
+
+	var modes []lokiv1.ModeType
+	if authz.Roles != nil || authz.RoleBindings != nil {
+		modes = append(modes, lokiv1.Static)
+	}
+	if authz.OPA != nil {
+		modes = append(modes, lokiv1.Dynamic)
+	}
+
+	return modes
+}
+
 // ValidateModes validates the tenants mode specification.
 func ValidateModes(stack lokiv1.LokiStack) error {
 	if stack.Spec.Tenants.Mode == lokiv1.Static {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -543,3 +544,101 @@ func TestValidateModes_OpenshiftLoggingMode(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateModeConflicts(t *testing.T) {
+	opa := &lokiv1.OPASpec{URL: "some-url"}
+	roles := []lokiv1.RoleSpec{{Name: "some-name"}}
+	bindings := []lokiv1.RoleBindingsSpec{{Name: "some-name"}}
+
+	type test struct {
+		name    string
+		tenants lokiv1.TenantsSpec
+		wantMsg string
+	}
+	table := []test{
+		{
+			name: "static only",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.Static,
+				Authorization: &lokiv1.AuthorizationSpec{Roles: roles, RoleBindings: bindings},
+			},
+		},
+		{
+			name: "dynamic only",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.Dynamic,
+				Authorization: &lokiv1.AuthorizationSpec{OPA: opa},
+			},
+		},
+		{
+			name:    "openshift only",
+			tenants: lokiv1.TenantsSpec{Mode: lokiv1.OpenshiftLogging},
+		},
+		{
+			name: "openshift-logging with static roles",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.OpenshiftLogging,
+				Authorization: &lokiv1.AuthorizationSpec{Roles: roles},
+			},
+			wantMsg: "Conflicting tenants modes configured together: openshift-logging, static",
+		},
+		{
+			name: "openshift-network with static role bindings",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.OpenshiftNetwork,
+				Authorization: &lokiv1.AuthorizationSpec{RoleBindings: bindings},
+			},
+			wantMsg: "Conflicting tenants modes configured together: openshift-network, static",
+		},
+		{
+			name: "openshift-logging with OPA",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.OpenshiftLogging,
+				Authorization: &lokiv1.AuthorizationSpec{OPA: opa},
+			},
+			wantMsg: "Conflicting tenants modes configured together: openshift-logging, dynamic",
+		},
+		{
+			name: "static with OPA",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.Static,
+				Authorization: &lokiv1.AuthorizationSpec{OPA: opa, Roles: roles, RoleBindings: bindings},
+			},
+			wantMsg: "Conflicting tenants modes configured together: static, dynamic",
+		},
+		{
+			name: "dynamic with static roles",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.Dynamic,
+				Authorization: &lokiv1.AuthorizationSpec{OPA: opa, Roles: roles},
+			},
+			wantMsg: "Conflicting tenants modes configured together: dynamic, static",
+		},
+		{
+			name: "openshift-logging with static roles and OPA",
+			tenants: lokiv1.TenantsSpec{
+				Mode:          lokiv1.OpenshiftLogging,
+				Authorization: &lokiv1.AuthorizationSpec{OPA: opa, Roles: roles},
+			},
+			wantMsg: "Conflicting tenants modes configured together: openshift-logging, static, dynamic",
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateModeConflicts(&tst.tenants)
+			if tst.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonConflictingTenantsModes, degraded.Reason)
+			require.Equal(t, tst.wantMsg, degraded.Message)
+			require.True(t, degraded.Requeue)
+		})
+	}
+}
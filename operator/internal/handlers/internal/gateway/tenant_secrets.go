@@ -37,9 +37,11 @@ func GetTenantSecrets(
 		if err := k.Get(ctx, key, &gatewaySecret); err != nil {
 			if apierrors.IsNotFound(err) {
 				return nil, &status.DegradedError{
-					Message: fmt.Sprintf("Missing secrets for tenant %s", tenant.TenantName),
-					Reason:  lokiv1.ReasonMissingGatewayTenantSecret,
-					Requeue: true,
+					Message:        fmt.Sprintf("Missing secrets for tenant %s", tenant.TenantName),
+					Reason:         lokiv1.ReasonMissingGatewayTenantSecret,
+					Requeue:        true,
+					Remediation:    fmt.Sprintf("Create secret %s in namespace %s", key.Name, key.Namespace),
+					InvolvedObject: status.SecretReference(key.Namespace, key.Name),
 				}
 			}
 			return nil, kverrors.Wrap(err, "failed to lookup lokistack gateway tenant secret",
@@ -0,0 +1,97 @@
+package limits
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/prometheus/common/model"
+)
+
+// Validate checks the global and per-tenant limits for impossible values, i.e. negative
+// limits or a query timeout that is not a positive duration. Unset limits use the defaults
+// and are always valid. It returns a degraded error naming all invalid limit fields.
+func Validate(spec *lokiv1.LimitsSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	var invalid []string
+	if spec.Global != nil {
+		invalid = append(invalid, validateTemplate("global", spec.Global)...)
+	}
+
+	tenants := make([]string, 0, len(spec.Tenants))
+	for name := range spec.Tenants {
+		tenants = append(tenants, name)
+	}
+	sort.Strings(tenants)
+
+	for _, name := range tenants {
+		t := spec.Tenants[name]
+		invalid = append(invalid, validateTemplate(fmt.Sprintf("tenants.%s", name), &t)...)
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message: fmt.Sprintf("Invalid limits configuration: %s", strings.Join(invalid, ", ")),
+		Reason:  lokiv1.ReasonInvalidLimitsConfiguration,
+		Requeue: false,
+	}
+}
+
+func validateTemplate(path string, spec *lokiv1.LimitsTemplateSpec) []string {
+	var invalid []string
+
+	if il := spec.IngestionLimits; il != nil {
+		fields := []struct {
+			name  string
+			value int32
+		}{
+			{name: "ingestionRate", value: il.IngestionRate},
+			{name: "ingestionBurstSize", value: il.IngestionBurstSize},
+			{name: "maxLabelNameLength", value: il.MaxLabelNameLength},
+			{name: "maxLabelValueLength", value: il.MaxLabelValueLength},
+			{name: "maxLabelNamesPerSeries", value: il.MaxLabelNamesPerSeries},
+			{name: "maxGlobalStreamsPerTenant", value: il.MaxGlobalStreamsPerTenant},
+			{name: "maxLineSize", value: il.MaxLineSize},
+		}
+
+		for _, f := range fields {
+			if f.value < 0 {
+				invalid = append(invalid, fmt.Sprintf("%s.ingestion.%s must not be negative (%d)", path, f.name, f.value))
+			}
+		}
+	}
+
+	if ql := spec.QueryLimits; ql != nil {
+		fields := []struct {
+			name  string
+			value int32
+		}{
+			{name: "maxEntriesLimitPerQuery", value: ql.MaxEntriesLimitPerQuery},
+			{name: "maxChunksPerQuery", value: ql.MaxChunksPerQuery},
+			{name: "maxQuerySeries", value: ql.MaxQuerySeries},
+		}
+
+		for _, f := range fields {
+			if f.value < 0 {
+				invalid = append(invalid, fmt.Sprintf("%s.queries.%s must not be negative (%d)", path, f.name, f.value))
+			}
+		}
+
+		if ql.QueryTimeout != "" {
+			if d, err := model.ParseDuration(ql.QueryTimeout); err != nil || d <= 0 {
+				invalid = append(invalid, fmt.Sprintf("%s.queries.queryTimeout must be a positive duration (%s)", path, ql.QueryTimeout))
+			}
+		}
+	}
+
+	return invalid
+}
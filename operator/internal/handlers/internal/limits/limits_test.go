@@ -0,0 +1,130 @@
+package limits_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/limits"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	type test struct {
+		name    string
+		spec    *lokiv1.LimitsSpec
+		wantMsg string
+	}
+	table := []test{
+		{
+			name: "no limits",
+		},
+		{
+			name: "unset limits use defaults",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{},
+					QueryLimits:     &lokiv1.QueryLimitSpec{},
+				},
+			},
+		},
+		{
+			name: "valid limits",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						IngestionRate:          4,
+						MaxLabelNamesPerSeries: 30,
+					},
+					QueryLimits: &lokiv1.QueryLimitSpec{
+						MaxQuerySeries: 500,
+						QueryTimeout:   "3m",
+					},
+				},
+			},
+		},
+		{
+			name: "negative max label names per series",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						MaxLabelNamesPerSeries: -1,
+					},
+				},
+			},
+			wantMsg: "Invalid limits configuration: global.ingestion.maxLabelNamesPerSeries must not be negative (-1)",
+		},
+		{
+			name: "negative max line size",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						MaxLineSize: -256,
+					},
+				},
+			},
+			wantMsg: "Invalid limits configuration: global.ingestion.maxLineSize must not be negative (-256)",
+		},
+		{
+			name: "negative max query series",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					QueryLimits: &lokiv1.QueryLimitSpec{
+						MaxQuerySeries: -10,
+					},
+				},
+			},
+			wantMsg: "Invalid limits configuration: global.queries.maxQuerySeries must not be negative (-10)",
+		},
+		{
+			name: "invalid query timeout",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					QueryLimits: &lokiv1.QueryLimitSpec{
+						QueryTimeout: "soon",
+					},
+				},
+			},
+			wantMsg: "Invalid limits configuration: global.queries.queryTimeout must be a positive duration (soon)",
+		},
+		{
+			name: "multiple tenants in stable order",
+			spec: &lokiv1.LimitsSpec{
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"infrastructure": {
+						IngestionLimits: &lokiv1.IngestionLimitSpec{
+							IngestionBurstSize: -1,
+						},
+					},
+					"application": {
+						QueryLimits: &lokiv1.QueryLimitSpec{
+							MaxChunksPerQuery: -2,
+							QueryTimeout:      "0s",
+						},
+					},
+				},
+			},
+			wantMsg: "Invalid limits configuration: " +
+				"tenants.application.queries.maxChunksPerQuery must not be negative (-2), " +
+				"tenants.application.queries.queryTimeout must be a positive duration (0s), " +
+				"tenants.infrastructure.ingestion.ingestionBurstSize must not be negative (-1)",
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := limits.Validate(tst.spec)
+			if tst.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInvalidLimitsConfiguration, degraded.Reason)
+			require.Equal(t, tst.wantMsg, degraded.Message)
+		})
+	}
+}
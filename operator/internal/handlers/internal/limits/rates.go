@@ -0,0 +1,89 @@
+package limits
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+)
+
+// bytesPerMB converts the ingestion rate and burst size limits given in MB to bytes.
+const bytesPerMB = 1024 * 1024
+
+// ValidateIngestionRates checks the ingestion limits of the global and per-tenant limits against
+// each other. Per-tenant limits fall back to the global limits for unset fields, as the per-tenant
+// overrides do. The burst size must not be lower than the ingestion rate, else pushes below the
+// tenant rate are rejected, and it must admit a line of the maximum line size, else such lines are
+// never ingested. Unset limits use the consistent defaults and are not compared. It returns a
+// degraded error naming all conflicting limit fields.
+func ValidateIngestionRates(spec *lokiv1.LimitsSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	var global lokiv1.IngestionLimitSpec
+	if spec.Global != nil && spec.Global.IngestionLimits != nil {
+		global = *spec.Global.IngestionLimits
+	}
+
+	conflicts := ingestionRateConflicts("global", global)
+
+	tenants := make([]string, 0, len(spec.Tenants))
+	for name := range spec.Tenants {
+		tenants = append(tenants, name)
+	}
+	sort.Strings(tenants)
+
+	for _, name := range tenants {
+		il := spec.Tenants[name].IngestionLimits
+		if il == nil {
+			continue
+		}
+
+		effective := global
+		if il.IngestionRate != 0 {
+			effective.IngestionRate = il.IngestionRate
+		}
+		if il.IngestionBurstSize != 0 {
+			effective.IngestionBurstSize = il.IngestionBurstSize
+		}
+		if il.MaxLineSize != 0 {
+			effective.MaxLineSize = il.MaxLineSize
+		}
+
+		if effective == global {
+			// Conflicts inherited from the global limits are reported once
+			continue
+		}
+
+		conflicts = append(conflicts, ingestionRateConflicts(fmt.Sprintf("tenants.%s", name), effective)...)
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message: fmt.Sprintf("Conflicting ingestion limits: %s", strings.Join(conflicts, ", ")),
+		Reason:  lokiv1.ReasonConflictingIngestionLimits,
+		Requeue: false,
+	}
+}
+
+func ingestionRateConflicts(path string, il lokiv1.IngestionLimitSpec) []string {
+	var conflicts []string
+
+	if il.IngestionBurstSize > 0 && il.IngestionRate > 0 && il.IngestionBurstSize < il.IngestionRate {
+		conflicts = append(conflicts, fmt.Sprintf("%s.ingestion.ingestionBurstSize (%dMB) is lower than ingestionRate (%dMB)",
+			path, il.IngestionBurstSize, il.IngestionRate))
+	}
+
+	if il.IngestionBurstSize > 0 && il.MaxLineSize > 0 && int64(il.IngestionBurstSize)*bytesPerMB < int64(il.MaxLineSize) {
+		conflicts = append(conflicts, fmt.Sprintf("%s.ingestion.ingestionBurstSize (%dMB) is lower than maxLineSize (%d bytes)",
+			path, il.IngestionBurstSize, il.MaxLineSize))
+	}
+
+	return conflicts
+}
@@ -0,0 +1,140 @@
+package limits_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/limits"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIngestionRates(t *testing.T) {
+	type test struct {
+		name    string
+		spec    *lokiv1.LimitsSpec
+		wantMsg string
+	}
+	table := []test{
+		{
+			name: "no limits",
+		},
+		{
+			name: "unset limits use defaults",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{},
+				},
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"application": {},
+				},
+			},
+		},
+		{
+			name: "consistent limits",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						IngestionRate:      4,
+						IngestionBurstSize: 6,
+						MaxLineSize:        256000,
+					},
+				},
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"application": {
+						IngestionLimits: &lokiv1.IngestionLimitSpec{
+							IngestionRate: 6,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "burst size lower than rate",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						IngestionRate:      10,
+						IngestionBurstSize: 5,
+					},
+				},
+			},
+			wantMsg: "Conflicting ingestion limits: global.ingestion.ingestionBurstSize (5MB) is lower than ingestionRate (10MB)",
+		},
+		{
+			name: "burst size lower than max line size",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						IngestionBurstSize: 1,
+						MaxLineSize:        2097152,
+					},
+				},
+			},
+			wantMsg: "Conflicting ingestion limits: global.ingestion.ingestionBurstSize (1MB) is lower than maxLineSize (2097152 bytes)",
+		},
+		{
+			name: "tenant rate exceeds global burst size",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						IngestionRate:      4,
+						IngestionBurstSize: 6,
+					},
+				},
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"infrastructure": {
+						IngestionLimits: &lokiv1.IngestionLimitSpec{
+							IngestionRate: 8,
+						},
+					},
+					"application": {
+						IngestionLimits: &lokiv1.IngestionLimitSpec{
+							IngestionRate:      8,
+							IngestionBurstSize: 8,
+						},
+					},
+				},
+			},
+			wantMsg: "Conflicting ingestion limits: tenants.infrastructure.ingestion.ingestionBurstSize (6MB) is lower than ingestionRate (8MB)",
+		},
+		{
+			name: "global conflict reported once",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					IngestionLimits: &lokiv1.IngestionLimitSpec{
+						IngestionRate:      10,
+						IngestionBurstSize: 5,
+					},
+				},
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"application": {
+						IngestionLimits: &lokiv1.IngestionLimitSpec{
+							MaxLabelNameLength: 1024,
+						},
+					},
+				},
+			},
+			wantMsg: "Conflicting ingestion limits: global.ingestion.ingestionBurstSize (5MB) is lower than ingestionRate (10MB)",
+		},
+	}
+
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := limits.ValidateIngestionRates(tst.spec)
+			if tst.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonConflictingIngestionLimits, degraded.Reason)
+			require.Equal(t, tst.wantMsg, degraded.Message)
+			require.False(t, degraded.Requeue)
+		})
+	}
+}
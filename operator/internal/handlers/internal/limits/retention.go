@@ -0,0 +1,76 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/grafana/loki/pkg/logql/syntax"
+)
+
+// ValidateRetentionSelectors parses the LogQL stream selectors of the global and per-tenant retention
+// streams, e.g. {namespace="prod"}, which the compactor otherwise fails to apply. Retention without
+// streams applies to all logs and is always valid. It returns a degraded error naming all invalid selectors
+// with their parse error.
+func ValidateRetentionSelectors(spec *lokiv1.LimitsSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	var invalid []string
+	if spec.Global != nil {
+		invalid = append(invalid, validateRetention("global", spec.Global.Retention)...)
+	}
+
+	tenants := make([]string, 0, len(spec.Tenants))
+	for name := range spec.Tenants {
+		tenants = append(tenants, name)
+	}
+	sort.Strings(tenants)
+
+	for _, name := range tenants {
+		invalid = append(invalid, validateRetention(fmt.Sprintf("tenants.%s", name), spec.Tenants[name].Retention)...)
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message: fmt.Sprintf("Invalid retention stream selectors: %s", strings.Join(invalid, "; ")),
+		Reason:  lokiv1.ReasonInvalidRetentionStreamSelector,
+		Requeue: false,
+	}
+}
+
+func validateRetention(path string, spec *lokiv1.RetentionLimitSpec) []string {
+	if spec == nil {
+		return nil
+	}
+
+	var invalid []string
+	for i, stream := range spec.Streams {
+		if stream == nil {
+			continue
+		}
+
+		if err := parseStreamSelector(stream.Selector); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s.retention.streams[%d].selector %s: %s", path, i, stream.Selector, err))
+		}
+	}
+
+	return invalid
+}
+
+func parseStreamSelector(selector string) error {
+	if !strings.HasPrefix(strings.TrimSpace(selector), "{") {
+		return errors.New("stream selector must start with {")
+	}
+
+	_, err := syntax.ParseMatchers(selector)
+	return err
+}
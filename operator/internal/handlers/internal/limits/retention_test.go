@@ -0,0 +1,95 @@
+package limits_test
+
+import (
+	"strings"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/limits"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRetentionSelectors(t *testing.T) {
+	streams := func(selectors ...string) *lokiv1.RetentionLimitSpec {
+		spec := &lokiv1.RetentionLimitSpec{Days: 7}
+		for _, s := range selectors {
+			spec.Streams = append(spec.Streams, &lokiv1.RetentionStreamSpec{Days: 1, Selector: s})
+		}
+		return spec
+	}
+
+	type test struct {
+		name        string
+		spec        *lokiv1.LimitsSpec
+		wantInvalid []string
+	}
+	table := []test{
+		{
+			name: "no limits",
+		},
+		{
+			name: "no retention",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{},
+			},
+		},
+		{
+			name: "global retention without streams",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{Retention: streams()},
+			},
+		},
+		{
+			name: "valid selectors",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					Retention: streams(`{namespace="prod"}`, `{namespace="dev", app=~"api-.+"}`),
+				},
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"application": {Retention: streams(`{ level != "debug" }`)},
+				},
+			},
+		},
+		{
+			name: "malformed selectors",
+			spec: &lokiv1.LimitsSpec{
+				Global: &lokiv1.LimitsTemplateSpec{
+					Retention: streams(`{namespace="prod"}`, `{namespace=}`),
+				},
+				Tenants: map[string]lokiv1.LimitsTemplateSpec{
+					"infrastructure": {Retention: streams(`{app=~"("}`)},
+					"application":    {Retention: streams(`namespace="prod"`, `{}`)},
+				},
+			},
+			wantInvalid: []string{
+				`global.retention.streams[1].selector {namespace=}: parse error`,
+				`tenants.application.retention.streams[0].selector namespace="prod": stream selector must start with {`,
+				`tenants.application.retention.streams[1].selector {}: parse error`,
+				`tenants.infrastructure.retention.streams[0].selector {app=~"("}: parse error`,
+			},
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := limits.ValidateRetentionSelectors(tst.spec)
+			if len(tst.wantInvalid) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInvalidRetentionStreamSelector, degraded.Reason)
+			require.True(t, strings.HasPrefix(degraded.Message, "Invalid retention stream selectors: "))
+			for _, invalid := range tst.wantInvalid {
+				require.Contains(t, degraded.Message, invalid)
+			}
+			require.Len(t, strings.Split(degraded.Message, "; "), len(tst.wantInvalid))
+			require.False(t, degraded.Requeue)
+		})
+	}
+}
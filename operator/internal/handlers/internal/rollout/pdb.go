@@ -0,0 +1,199 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/grafana/loki/operator/internal/status"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// workload describes the rollout state of a LokiStack deployment or statefulset.
+type workload struct {
+	name      string
+	podLabels labels.Set
+	stalled   bool
+}
+
+// ValidatePodDisruptionBudgets returns a degraded error if the rollout of any LokiStack deployment
+// or statefulset is stuck on a PodDisruptionBudget allowing no further disruptions. Rolling updates
+// replace pods without the eviction API, thus a PodDisruptionBudget alone never blocks them. Only
+// a real stall of a workload whose pods the PodDisruptionBudget selects is reported:
+// - A deployment rollout exceeding its progress deadline.
+// - A node drain stuck on evicting a pod, i.e. a pod running on a cordoned node.
+func ValidatePodDisruptionBudgets(ctx context.Context, k k8s.Client, stackName, namespace string) error {
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := k.List(ctx, &pdbs, client.InNamespace(namespace)); err != nil {
+		return kverrors.Wrap(err, "failed to list poddisruptionbudgets", "namespace", namespace)
+	}
+
+	var blocking []policyv1.PodDisruptionBudget
+	for _, pdb := range pdbs.Items {
+		if pdb.Spec.Selector != nil && pdb.Status.DisruptionsAllowed == 0 {
+			blocking = append(blocking, pdb)
+		}
+	}
+
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	workloads, err := listWorkloads(ctx, k, stackName, namespace)
+	if err != nil {
+		return err
+	}
+
+	draining, err := podsOnCordonedNodes(ctx, k, stackName, namespace)
+	if err != nil {
+		return err
+	}
+
+	blocked := map[string]string{}
+	for _, w := range workloads {
+		if !w.stalled && !anyMatches(draining, w.podLabels) {
+			continue
+		}
+
+		for _, pdb := range blocking {
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || selector.Empty() || !selector.Matches(w.podLabels) {
+				continue
+			}
+
+			blocked[w.name] = pdb.Name
+			break
+		}
+	}
+
+	if len(blocked) == 0 {
+		return nil
+	}
+
+	pdbNames := make([]string, 0, len(blocked))
+	for _, name := range blocked {
+		pdbNames = append(pdbNames, name)
+	}
+	sort.Strings(pdbNames)
+
+	return &status.DegradedError{
+		Message:     fmt.Sprintf("Rollout blocked by PodDisruptionBudgets allowing no disruptions: %s", status.FormatMap(blocked, "%s (PodDisruptionBudget %s)")),
+		Reason:      lokiv1.ReasonRolloutBlockedByPodDisruptionBudget,
+		Requeue:     true,
+		Remediation: "Relax the PodDisruptionBudget to allow at least one disruption or remove it",
+		InvolvedObject: &status.InvolvedObject{
+			Group:     policyv1.GroupName,
+			Kind:      "PodDisruptionBudget",
+			Name:      pdbNames[0],
+			Namespace: namespace,
+		},
+	}
+}
+
+func listWorkloads(ctx context.Context, k k8s.Client, stackName, namespace string) ([]workload, error) {
+	opts := []client.ListOption{
+		client.MatchingLabels(manifests.StackLabels(stackName)),
+		client.InNamespace(namespace),
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := k.List(ctx, &deployments, opts...); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list LokiStack deployments", "name", stackName)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := k.List(ctx, &statefulSets, opts...); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list LokiStack statefulsets", "name", stackName)
+	}
+
+	var workloads []workload
+	for _, d := range deployments.Items {
+		workloads = append(workloads, workload{
+			name:      d.Name,
+			podLabels: d.Spec.Template.Labels,
+			stalled:   progressDeadlineExceeded(d.Status.Conditions),
+		})
+	}
+
+	// Statefulsets have no progress deadline, only a stuck drain reveals a stall
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, workload{
+			name:      s.Name,
+			podLabels: s.Spec.Template.Labels,
+		})
+	}
+
+	return workloads, nil
+}
+
+func progressDeadlineExceeded(conditions []appsv1.DeploymentCondition) bool {
+	for _, c := range conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse {
+			return c.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
+
+// podsOnCordonedNodes returns the labels of the LokiStack pods running on cordoned nodes, i.e.
+// pods waiting for their eviction by a node drain. Nodes that cannot be read are considered
+// schedulable.
+func podsOnCordonedNodes(ctx context.Context, k k8s.Client, stackName, namespace string) ([]labels.Set, error) {
+	var pods corev1.PodList
+	opts := []client.ListOption{
+		client.MatchingLabels(manifests.StackLabels(stackName)),
+		client.InNamespace(namespace),
+	}
+	if err := k.List(ctx, &pods, opts...); err != nil {
+		return nil, kverrors.Wrap(err, "failed to list LokiStack pods", "name", stackName)
+	}
+
+	cordoned := map[string]bool{}
+	var draining []labels.Set
+	for _, pod := range pods.Items {
+		name := pod.Spec.NodeName
+		if name == "" {
+			continue
+		}
+
+		unschedulable, ok := cordoned[name]
+		if !ok {
+			var node corev1.Node
+			if err := k.Get(ctx, client.ObjectKey{Name: name}, &node); err != nil {
+				if !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+					return nil, kverrors.Wrap(err, "failed to lookup node", "name", name)
+				}
+			}
+			unschedulable = node.Spec.Unschedulable
+			cordoned[name] = unschedulable
+		}
+
+		if unschedulable {
+			draining = append(draining, pod.Labels)
+		}
+	}
+
+	return draining, nil
+}
+
+// anyMatches returns true if the labels of any pod contain the pod template labels.
+func anyMatches(pods []labels.Set, podLabels labels.Set) bool {
+	selector := labels.SelectorFromSet(podLabels)
+	for _, l := range pods {
+		if selector.Matches(l) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package rollout_test
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/handlers/internal/rollout"
+	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func pdb(name string, component string, allowed int32) policyv1.PodDisruptionBudget {
+	return policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-ns"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/component": component},
+			},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+	}
+}
+
+func ingester(status appsv1.StatefulSetStatus) appsv1.StatefulSet {
+	return appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-stack-ingester", Namespace: "some-ns"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(3),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: manifests.ComponentLabels(manifests.LabelIngesterComponent, "my-stack"),
+				},
+			},
+		},
+		Status: status,
+	}
+}
+
+func querier(conditions ...appsv1.DeploymentCondition) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-stack-querier", Namespace: "some-ns"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: manifests.ComponentLabels(manifests.LabelQuerierComponent, "my-stack"),
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:            2,
+			UpdatedReplicas:     1,
+			UnavailableReplicas: 1,
+			Conditions:          conditions,
+		},
+	}
+}
+
+func ingesterPod(node string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack-ingester-0",
+			Namespace: "some-ns",
+			Labels:    manifests.ComponentLabels(manifests.LabelIngesterComponent, "my-stack"),
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+	}
+}
+
+func TestValidatePodDisruptionBudgets(t *testing.T) {
+	rolling := appsv1.StatefulSetStatus{
+		Replicas:          3,
+		AvailableReplicas: 2,
+		UpdatedReplicas:   1,
+		CurrentRevision:   "my-stack-ingester-1",
+		UpdateRevision:    "my-stack-ingester-2",
+	}
+	progressing := appsv1.DeploymentCondition{
+		Type:   appsv1.DeploymentProgressing,
+		Status: corev1.ConditionTrue,
+		Reason: "ReplicaSetUpdated",
+	}
+	deadlineExceeded := appsv1.DeploymentCondition{
+		Type:   appsv1.DeploymentProgressing,
+		Status: corev1.ConditionFalse,
+		Reason: "ProgressDeadlineExceeded",
+	}
+	nodes := map[string]corev1.Node{
+		"node-a": {ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		"node-b": {ObjectMeta: metav1.ObjectMeta{Name: "node-b"}, Spec: corev1.NodeSpec{Unschedulable: true}},
+	}
+
+	table := []struct {
+		name       string
+		pdbs       []policyv1.PodDisruptionBudget
+		deployment appsv1.Deployment
+		pods       []corev1.Pod
+		wantMsg    string
+	}{
+		{
+			name:       "no pdbs",
+			deployment: querier(deadlineExceeded),
+		},
+		{
+			name:       "pdb allows disruptions",
+			pdbs:       []policyv1.PodDisruptionBudget{pdb("querier-pdb", manifests.LabelQuerierComponent, 1)},
+			deployment: querier(deadlineExceeded),
+		},
+		{
+			name:       "pdb selects other pods",
+			pdbs:       []policyv1.PodDisruptionBudget{pdb("ingester-pdb", manifests.LabelIngesterComponent, 0)},
+			deployment: querier(deadlineExceeded),
+		},
+		{
+			name: "healthy rollout with zero-budget pdbs",
+			pdbs: []policyv1.PodDisruptionBudget{
+				pdb("querier-pdb", manifests.LabelQuerierComponent, 0),
+				pdb("ingester-pdb", manifests.LabelIngesterComponent, 0),
+			},
+			deployment: querier(progressing),
+			pods:       []corev1.Pod{ingesterPod("node-a")},
+		},
+		{
+			name:       "deployment rollout exceeding progress deadline",
+			pdbs:       []policyv1.PodDisruptionBudget{pdb("querier-pdb", manifests.LabelQuerierComponent, 0)},
+			deployment: querier(deadlineExceeded),
+			wantMsg:    "Rollout blocked by PodDisruptionBudgets allowing no disruptions: my-stack-querier (PodDisruptionBudget querier-pdb)",
+		},
+		{
+			name:       "node drain stuck on eviction",
+			pdbs:       []policyv1.PodDisruptionBudget{pdb("ingester-pdb", manifests.LabelIngesterComponent, 0)},
+			deployment: querier(progressing),
+			pods:       []corev1.Pod{ingesterPod("node-b")},
+			wantMsg:    "Rollout blocked by PodDisruptionBudgets allowing no disruptions: my-stack-ingester (PodDisruptionBudget ingester-pdb)",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			k := &k8sfakes.FakeClient{}
+			k.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				switch list.(type) {
+				case *policyv1.PodDisruptionBudgetList:
+					k.SetClientObjectList(list, &policyv1.PodDisruptionBudgetList{Items: tc.pdbs})
+				case *appsv1.DeploymentList:
+					k.SetClientObjectList(list, &appsv1.DeploymentList{Items: []appsv1.Deployment{tc.deployment}})
+				case *appsv1.StatefulSetList:
+					k.SetClientObjectList(list, &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{ingester(rolling)}})
+				case *corev1.PodList:
+					k.SetClientObjectList(list, &corev1.PodList{Items: tc.pods})
+				}
+				return nil
+			}
+			k.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				node, ok := nodes[key.Name]
+				if !ok {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, key.Name)
+				}
+				k.SetClientObject(obj, &node)
+				return nil
+			}
+
+			err := rollout.ValidatePodDisruptionBudgets(context.Background(), k, "my-stack", "some-ns")
+			if tc.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonRolloutBlockedByPodDisruptionBudget, degraded.Reason)
+			require.Equal(t, tc.wantMsg, degraded.Message)
+			require.True(t, degraded.Requeue)
+			require.Equal(t, "PodDisruptionBudget", degraded.InvolvedObject.Kind)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/grafana/loki/pkg/logql/syntax"
+)
+
+// ValidateExpressions parses the LogQL expressions of the alerting and recording rules selected
+// by the LokiStack, which the ruler otherwise rejects at runtime. Rules created before enabling
+// the validating webhooks or with the webhooks disabled are caught this way. An expression must
+// parse and evaluate to a sample, i.e. a metric query. No rules are always valid. It returns a
+// degraded error naming all invalid rules with their parse error.
+func ValidateExpressions(alerts []lokiv1beta1.AlertingRule, recs []lokiv1beta1.RecordingRule) error {
+	var invalid []string
+	for _, ar := range alerts {
+		for _, g := range ar.Spec.Groups {
+			if g == nil {
+				continue
+			}
+			for i, r := range g.Rules {
+				if r == nil {
+					continue
+				}
+				if err := parseSampleExpr(r.Expr); err != nil {
+					invalid = append(invalid, fmt.Sprintf("AlertingRule %s/%s group %s rule %s: %s", ar.Namespace, ar.Name, g.Name, ruleName(r.Alert, i), err))
+				}
+			}
+		}
+	}
+
+	for _, rr := range recs {
+		for _, g := range rr.Spec.Groups {
+			if g == nil {
+				continue
+			}
+			for i, r := range g.Rules {
+				if r == nil {
+					continue
+				}
+				if err := parseSampleExpr(r.Expr); err != nil {
+					invalid = append(invalid, fmt.Sprintf("RecordingRule %s/%s group %s rule %s: %s", rr.Namespace, rr.Name, g.Name, ruleName(r.Record, i), err))
+				}
+			}
+		}
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message: fmt.Sprintf("Invalid LogQL expressions in rules: %s", strings.Join(invalid, "; ")),
+		Reason:  lokiv1.ReasonInvalidRuleExpression,
+		Requeue: false,
+	}
+}
+
+func parseSampleExpr(expr string) error {
+	e, err := syntax.ParseExpr(expr)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := e.(syntax.SampleExpr); !ok {
+		return lokiv1beta1.ErrParseLogQLNotSample
+	}
+
+	return nil
+}
+
+// ruleName returns the name of the rule or its index within the group if unnamed.
+func ruleName(name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("#%d", index)
+	}
+	return name
+}
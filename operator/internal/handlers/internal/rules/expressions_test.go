@@ -0,0 +1,83 @@
+package rules_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/rules"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateExpressions(t *testing.T) {
+	alerting := func(name string, exprs ...string) lokiv1beta1.AlertingRule {
+		g := &lokiv1beta1.AlertingRuleGroup{Name: "alerts"}
+		for _, e := range exprs {
+			g.Rules = append(g.Rules, &lokiv1beta1.AlertingRuleGroupSpec{Alert: "HighErrorRate", Expr: e})
+		}
+		return lokiv1beta1.AlertingRule{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-ns"},
+			Spec:       lokiv1beta1.AlertingRuleSpec{Groups: []*lokiv1beta1.AlertingRuleGroup{g}},
+		}
+	}
+	recording := func(name string, exprs ...string) lokiv1beta1.RecordingRule {
+		g := &lokiv1beta1.RecordingRuleGroup{Name: "records"}
+		for _, e := range exprs {
+			g.Rules = append(g.Rules, &lokiv1beta1.RecordingRuleGroupSpec{Expr: e})
+		}
+		return lokiv1beta1.RecordingRule{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-ns"},
+			Spec:       lokiv1beta1.RecordingRuleSpec{Groups: []*lokiv1beta1.RecordingRuleGroup{g}},
+		}
+	}
+
+	const valid = `sum(rate({app="api"} |= "error" [5m])) by (job) > 0.1`
+
+	type test struct {
+		name      string
+		alerts    []lokiv1beta1.AlertingRule
+		recs      []lokiv1beta1.RecordingRule
+		wantRules []string
+	}
+	table := []test{
+		{
+			name: "no rules",
+		},
+		{
+			name:   "valid expressions",
+			alerts: []lokiv1beta1.AlertingRule{alerting("my-alerts", valid)},
+			recs:   []lokiv1beta1.RecordingRule{recording("my-records", `count_over_time({app="api"}[1m])`)},
+		},
+		{
+			name:   "invalid expressions",
+			alerts: []lokiv1beta1.AlertingRule{alerting("my-alerts", valid, `sum(rate({app="api"}[5m]`)},
+			recs:   []lokiv1beta1.RecordingRule{recording("my-records", `{app="api"} |= "error"`)},
+			wantRules: []string{
+				"AlertingRule some-ns/my-alerts group alerts rule HighErrorRate: ",
+				"RecordingRule some-ns/my-records group records rule #0: " + lokiv1beta1.ErrParseLogQLNotSample.Error(),
+			},
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := rules.ValidateExpressions(tst.alerts, tst.recs)
+			if len(tst.wantRules) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInvalidRuleExpression, degraded.Reason)
+			require.False(t, degraded.Requeue)
+			for _, want := range tst.wantRules {
+				require.Contains(t, degraded.Message, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/prometheus/common/model"
+)
+
+// ValidateExternalLabels checks the external labels the ruler adds to all alerts sent to the
+// alertmanager, globally and per tenant. Alertmanager drops alerts with invalid labels, thus
+// alerting breaks without any visible error on the LokiStack. Unset external labels are valid.
+// It returns a degraded error naming all invalid labels.
+func ValidateExternalLabels(spec *lokiv1beta1.RulerConfigSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	var invalid []string
+	if spec.AlertManagerSpec != nil {
+		invalid = append(invalid, validateExternalLabels("alertmanager", spec.AlertManagerSpec.ExternalLabels)...)
+	}
+
+	tenants := make([]string, 0, len(spec.Overrides))
+	for name := range spec.Overrides {
+		tenants = append(tenants, name)
+	}
+	sort.Strings(tenants)
+
+	for _, name := range tenants {
+		am := spec.Overrides[name].AlertManagerOverrides
+		if am == nil {
+			continue
+		}
+		invalid = append(invalid, validateExternalLabels(fmt.Sprintf("overrides.%s.alertmanager", name), am.ExternalLabels)...)
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message: fmt.Sprintf("Invalid ruler external labels: %s", strings.Join(invalid, "; ")),
+		Reason:  lokiv1.ReasonInvalidRulerExternalLabels,
+		Requeue: false,
+	}
+}
+
+func validateExternalLabels(path string, labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var invalid []string
+	for _, name := range names {
+		switch {
+		case !model.LabelName(name).IsValid():
+			invalid = append(invalid, fmt.Sprintf("%s.externalLabels %q: invalid label name", path, name))
+		case strings.HasPrefix(name, model.ReservedLabelPrefix):
+			invalid = append(invalid, fmt.Sprintf("%s.externalLabels %q: label names starting with %s are reserved", path, name, model.ReservedLabelPrefix))
+		case !model.LabelValue(labels[name]).IsValid():
+			invalid = append(invalid, fmt.Sprintf("%s.externalLabels %q: label value is not valid UTF-8", path, name))
+		}
+	}
+
+	return invalid
+}
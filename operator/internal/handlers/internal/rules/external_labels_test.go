@@ -0,0 +1,95 @@
+package rules_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/rules"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExternalLabels(t *testing.T) {
+	type test struct {
+		name    string
+		spec    *lokiv1beta1.RulerConfigSpec
+		wantMsg string
+	}
+	table := []test{
+		{
+			name: "no ruler config",
+		},
+		{
+			name: "no alertmanager",
+			spec: &lokiv1beta1.RulerConfigSpec{},
+		},
+		{
+			name: "no external labels",
+			spec: &lokiv1beta1.RulerConfigSpec{
+				AlertManagerSpec: &lokiv1beta1.AlertManagerSpec{},
+				Overrides: map[string]lokiv1beta1.RulerOverrides{
+					"application": {},
+				},
+			},
+		},
+		{
+			name: "valid external labels",
+			spec: &lokiv1beta1.RulerConfigSpec{
+				AlertManagerSpec: &lokiv1beta1.AlertManagerSpec{
+					ExternalLabels: map[string]string{"cluster": "prod-eu", "team_name": "observability"},
+				},
+				Overrides: map[string]lokiv1beta1.RulerOverrides{
+					"application": {
+						AlertManagerOverrides: &lokiv1beta1.AlertManagerSpec{
+							ExternalLabels: map[string]string{"tenant": "application"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid external labels",
+			spec: &lokiv1beta1.RulerConfigSpec{
+				AlertManagerSpec: &lokiv1beta1.AlertManagerSpec{
+					ExternalLabels: map[string]string{"cluster": "prod", "team-name": "observability", "__region": "eu"},
+				},
+				Overrides: map[string]lokiv1beta1.RulerOverrides{
+					"infrastructure": {
+						AlertManagerOverrides: &lokiv1beta1.AlertManagerSpec{
+							ExternalLabels: map[string]string{"tenant": "infra\xff"},
+						},
+					},
+					"application": {
+						AlertManagerOverrides: &lokiv1beta1.AlertManagerSpec{
+							ExternalLabels: map[string]string{"1tenant": "application"},
+						},
+					},
+				},
+			},
+			wantMsg: "Invalid ruler external labels: " +
+				`alertmanager.externalLabels "__region": label names starting with __ are reserved; ` +
+				`alertmanager.externalLabels "team-name": invalid label name; ` +
+				`overrides.application.alertmanager.externalLabels "1tenant": invalid label name; ` +
+				`overrides.infrastructure.alertmanager.externalLabels "tenant": label value is not valid UTF-8`,
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := rules.ValidateExternalLabels(tst.spec)
+			if tst.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInvalidRulerExternalLabels, degraded.Reason)
+			require.Equal(t, tst.wantMsg, degraded.Message)
+			require.False(t, degraded.Requeue)
+		})
+	}
+}
@@ -1,14 +1,43 @@
 package rules
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/ViaQ/logerr/v2/kverrors"
 
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
 	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/grafana/loki/operator/internal/status"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// GetRemoteWriteSecret returns the ruler remote-write authorization secret from the given namespace.
+// It returns a degraded error naming the secret if it is missing.
+func GetRemoteWriteSecret(ctx context.Context, k k8s.Client, namespace, name string) (*corev1.Secret, error) {
+	var s corev1.Secret
+	key := client.ObjectKey{Name: name, Namespace: namespace}
+	if err := k.Get(ctx, key, &s); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &status.DegradedError{
+				Message:        fmt.Sprintf("Missing ruler remote write authorization secret: %s", name),
+				Reason:         lokiv1.ReasonMissingRulerSecret,
+				Requeue:        true,
+				Remediation:    fmt.Sprintf("Create secret %s in namespace %s", name, namespace),
+				InvolvedObject: status.SecretReference(namespace, name),
+			}
+		}
+		return nil, kverrors.Wrap(err, "failed to lookup lokistack ruler secret", "name", key)
+	}
+
+	return &s, nil
+}
+
 // ExtractRulerSecret reads a k8s secret infto a ruler secret struct if valid.
 func ExtractRulerSecret(s *corev1.Secret, t lokiv1beta1.RemoteWriteAuthType) (*manifests.RulerSecret, error) {
 	switch t {
@@ -1,13 +1,23 @@
 package rules_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
 	"github.com/grafana/loki/operator/internal/handlers/internal/rules"
 	"github.com/grafana/loki/operator/internal/manifests"
+	"github.com/grafana/loki/operator/internal/status"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestExtractRulerSecret(t *testing.T) {
@@ -84,3 +94,56 @@ func TestExtractRulerSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRemoteWriteSecret_WhenMissing_ReturnDegradedError(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, _ types.NamespacedName, _ client.Object, _ ...client.GetOption) error {
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+
+	_, err := rules.GetRemoteWriteSecret(context.TODO(), k, "some-ns", "remote-write-auth")
+
+	var degraded *status.DegradedError
+	require.ErrorAs(t, err, &degraded)
+	require.Equal(t, lokiv1.ReasonMissingRulerSecret, degraded.Reason)
+	require.Contains(t, degraded.Message, "remote-write-auth")
+	require.True(t, degraded.Requeue)
+}
+
+func TestGetRemoteWriteSecret_WhenPresent_ReturnSecret(t *testing.T) {
+	s := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote-write-auth",
+			Namespace: "some-ns",
+		},
+		Data: map[string][]byte{
+			"bearer_token": []byte("a-token"),
+		},
+	}
+
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		if name.Name == s.Name && name.Namespace == s.Namespace {
+			k.SetClientObject(object, &s)
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+
+	got, err := rules.GetRemoteWriteSecret(context.TODO(), k, "some-ns", "remote-write-auth")
+	require.NoError(t, err)
+	require.Equal(t, s.Data, got.Data)
+}
+
+func TestGetRemoteWriteSecret_WhenGetFails_ReturnError(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, _ types.NamespacedName, _ client.Object, _ ...client.GetOption) error {
+		return apierrors.NewBadRequest("something went wrong")
+	}
+
+	_, err := rules.GetRemoteWriteSecret(context.TODO(), k, "some-ns", "remote-write-auth")
+
+	var degraded *status.DegradedError
+	require.Error(t, err)
+	require.False(t, errors.As(err, &degraded))
+}
@@ -1,9 +1,73 @@
 package storage
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/status"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 // IsValidCAConfigMap checks if the given CA configMap has an
 // non-empty entry for the key
 func IsValidCAConfigMap(cm *corev1.ConfigMap, key string) bool {
 	return cm.Data[key] != ""
 }
+
+// GetCAConfigMap returns the object storage CA configmap from the given namespace.
+// It returns a degraded error naming the configmap if it is missing, the key has no
+// contents or the contents are not a PEM-encoded certificate.
+func GetCAConfigMap(ctx context.Context, k k8s.Client, namespace, name, key string) (*corev1.ConfigMap, error) {
+	var cm corev1.ConfigMap
+	ck := client.ObjectKey{Name: name, Namespace: namespace}
+	if err := k.Get(ctx, ck, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &status.DegradedError{
+				Message:        fmt.Sprintf("Missing object storage CA config map: %s", name),
+				Reason:         lokiv1.ReasonMissingObjectStorageCAConfigMap,
+				Requeue:        true,
+				Remediation:    fmt.Sprintf("Create config map %s in namespace %s", name, namespace),
+				InvolvedObject: status.ConfigMapReference(namespace, name),
+			}
+		}
+		return nil, kverrors.Wrap(err, "failed to lookup lokistack object storage CA config map", "name", ck)
+	}
+
+	if !IsValidCAConfigMap(&cm, key) {
+		return nil, &status.DegradedError{
+			Message:        fmt.Sprintf("Invalid object storage CA configmap contents: missing key %q or no contents in %s", key, name),
+			Reason:         lokiv1.ReasonInvalidObjectStorageCAConfigMap,
+			Requeue:        true,
+			InvolvedObject: status.ConfigMapReference(namespace, name),
+		}
+	}
+
+	if !isPEMCertificate(cm.Data[key]) {
+		return nil, &status.DegradedError{
+			Message:        fmt.Sprintf("Invalid object storage CA configmap contents: key %q in %s is not a PEM-encoded certificate", key, name),
+			Reason:         lokiv1.ReasonInvalidObjectStorageCAConfigMap,
+			Requeue:        true,
+			InvolvedObject: status.ConfigMapReference(namespace, name),
+		}
+	}
+
+	return &cm, nil
+}
+
+func isPEMCertificate(data string) bool {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return false
+	}
+
+	_, err := x509.ParseCertificate(block.Bytes)
+	return err == nil
+}
@@ -1,11 +1,21 @@
 package storage_test
 
 import (
+	"context"
 	"testing"
 
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
 	"github.com/grafana/loki/operator/internal/handlers/internal/storage"
+	"github.com/grafana/loki/operator/internal/status"
 	"github.com/stretchr/testify/require"
+
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestIsValidConfigMap(t *testing.T) {
@@ -47,3 +57,97 @@ func TestIsValidConfigMap(t *testing.T) {
 		})
 	}
 }
+
+const testCACert = `-----BEGIN CERTIFICATE-----
+MIIBeDCCAR+gAwIBAgIUUQ6x2Svu2H1XU2QunARxl41VLaMwCgYIKoZIzj0EAwIw
+EjEQMA4GA1UEAwwHdGVzdC1jYTAeFw0yNjEwMTQxNTA1MDVaFw0zNjEwMTExNTA1
+MDVaMBIxEDAOBgNVBAMMB3Rlc3QtY2EwWTATBgcqhkjOPQIBBggqhkjOPQMBBwNC
+AATNID7ei0tSkzoSwbGbQLbQULCV3SdaQV843ctiUIg9jajeufDnImhi7MLtxS4C
+Th6ZunO3SMOXBcGJXrbYTncEo1MwUTAdBgNVHQ4EFgQUyJ6ulheSxCq141xzmQvj
+dIzhlJQwHwYDVR0jBBgwFoAUyJ6ulheSxCq141xzmQvjdIzhlJQwDwYDVR0TAQH/
+BAUwAwEB/zAKBggqhkjOPQQDAgNHADBEAiEA6ms0L4LFVHes0ZQZLvEiuaJq4dsi
++2iPpym9sMlahL4CH2CVRXuUfDlDlFsx1M7mPgQm5UbC75/8XTjjqyxtag4=
+-----END CERTIFICATE-----`
+
+func TestGetCAConfigMap(t *testing.T) {
+	type test struct {
+		name    string
+		cm      *corev1.ConfigMap
+		wantErr *status.DegradedError
+	}
+	table := []test{
+		{
+			name: "missing CA configmap",
+			wantErr: &status.DegradedError{
+				Message:        "Missing object storage CA config map: my-ca",
+				Reason:         lokiv1.ReasonMissingObjectStorageCAConfigMap,
+				Requeue:        true,
+				Remediation:    "Create config map my-ca in namespace some-ns",
+				InvolvedObject: status.ConfigMapReference("some-ns", "my-ca"),
+			},
+		},
+		{
+			name: "empty CA data",
+			cm: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-ca", Namespace: "some-ns"},
+				Data: map[string]string{
+					"service-ca.crt": "",
+				},
+			},
+			wantErr: &status.DegradedError{
+				Message:        `Invalid object storage CA configmap contents: missing key "service-ca.crt" or no contents in my-ca`,
+				Reason:         lokiv1.ReasonInvalidObjectStorageCAConfigMap,
+				Requeue:        true,
+				InvolvedObject: status.ConfigMapReference("some-ns", "my-ca"),
+			},
+		},
+		{
+			name: "malformed CA data",
+			cm: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-ca", Namespace: "some-ns"},
+				Data: map[string]string{
+					"service-ca.crt": "-----BEGIN CERTIFICATE-----\nbm90LWEtY2VydA==\n-----END CERTIFICATE-----",
+				},
+			},
+			wantErr: &status.DegradedError{
+				Message:        `Invalid object storage CA configmap contents: key "service-ca.crt" in my-ca is not a PEM-encoded certificate`,
+				Reason:         lokiv1.ReasonInvalidObjectStorageCAConfigMap,
+				Requeue:        true,
+				InvolvedObject: status.ConfigMapReference("some-ns", "my-ca"),
+			},
+		},
+		{
+			name: "valid CA data",
+			cm: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-ca", Namespace: "some-ns"},
+				Data: map[string]string{
+					"service-ca.crt": testCACert,
+				},
+			},
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			k := &k8sfakes.FakeClient{}
+			k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+				if tst.cm != nil && name.Name == tst.cm.Name && name.Namespace == tst.cm.Namespace {
+					k.SetClientObject(object, tst.cm)
+					return nil
+				}
+				return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+			}
+
+			cm, err := storage.GetCAConfigMap(context.TODO(), k, "some-ns", "my-ca", "service-ca.crt")
+			if tst.wantErr != nil {
+				require.Equal(t, tst.wantErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tst.cm.Name, cm.Name)
+		})
+	}
+}
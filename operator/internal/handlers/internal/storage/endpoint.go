@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests/storage"
+	"github.com/grafana/loki/operator/internal/status"
+)
+
+// ValidateS3Endpoint returns a degraded error if the scheme of the S3 endpoint contradicts the
+// insecure flag of the object storage secret, i.e. an http endpoint without insecure set fails
+// the TLS handshake and an https endpoint with insecure set is contacted without TLS. Endpoints
+// without a scheme are accepted as is.
+func ValidateS3Endpoint(cfg *storage.S3StorageConfig) error {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		// Left to the object storage client to report
+		return nil
+	}
+
+	var msg string
+	switch {
+	case u.Scheme == "http" && !cfg.Insecure:
+		msg = fmt.Sprintf("Object storage endpoint %s uses http but the secret field insecure is not set to true", cfg.Endpoint)
+	case u.Scheme == "https" && cfg.Insecure:
+		msg = fmt.Sprintf("Object storage endpoint %s uses https but the secret field insecure is set to true", cfg.Endpoint)
+	default:
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message:     msg,
+		Reason:      lokiv1.ReasonInconsistentObjectStorageEndpoint,
+		Requeue:     true,
+		Remediation: "Use an endpoint scheme matching the insecure field of the object storage secret",
+	}
+}
@@ -0,0 +1,67 @@
+package storage_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/storage"
+	storageoptions "github.com/grafana/loki/operator/internal/manifests/storage"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateS3Endpoint(t *testing.T) {
+	table := []struct {
+		name     string
+		endpoint string
+		insecure bool
+		wantMsg  string
+	}{
+		{
+			name:     "http with insecure",
+			endpoint: "http://minio.minio.svc:9000",
+			insecure: true,
+		},
+		{
+			name:     "http without insecure",
+			endpoint: "http://minio.minio.svc:9000",
+			wantMsg:  "Object storage endpoint http://minio.minio.svc:9000 uses http but the secret field insecure is not set to true",
+		},
+		{
+			name:     "https with insecure",
+			endpoint: "https://s3.eu-central-1.amazonaws.com",
+			insecure: true,
+			wantMsg:  "Object storage endpoint https://s3.eu-central-1.amazonaws.com uses https but the secret field insecure is set to true",
+		},
+		{
+			name:     "https without insecure",
+			endpoint: "https://s3.eu-central-1.amazonaws.com",
+		},
+		{
+			name:     "no scheme",
+			endpoint: "s3.eu-central-1.amazonaws.com",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := storage.ValidateS3Endpoint(&storageoptions.S3StorageConfig{
+				Endpoint: tc.endpoint,
+				Insecure: tc.insecure,
+			})
+			if tc.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInconsistentObjectStorageEndpoint, degraded.Reason)
+			require.Equal(t, tc.wantMsg, degraded.Message)
+			require.True(t, degraded.Requeue)
+		})
+	}
+}
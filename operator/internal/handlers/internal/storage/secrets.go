@@ -1,14 +1,20 @@
 package storage
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/ViaQ/logerr/v2/kverrors"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	"github.com/grafana/loki/operator/internal/manifests/storage"
+	"github.com/grafana/loki/operator/internal/status"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
-// ExtractSecret reads a k8s secret into a manifest object storage struct if valid.
+// ExtractSecret reads a k8s secret into a manifest object storage struct if valid. A secret missing
+// keys mandatory for the object storage type returns a degraded error naming all missing keys.
 func ExtractSecret(s *corev1.Secret, secretType lokiv1.ObjectStorageSecretType) (*storage.Options, error) {
 	var err error
 	storageOpts := storage.Options{
@@ -37,22 +43,13 @@ func ExtractSecret(s *corev1.Secret, secretType lokiv1.ObjectStorageSecretType)
 
 func extractAzureConfigSecret(s *corev1.Secret) (*storage.AzureStorageConfig, error) {
 	// Extract and validate mandatory fields
-	env := s.Data["environment"]
-	if len(env) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "environment")
+	if err := requireSecretFields(s, lokiv1.ObjectStorageSecretAzure, "environment", "container", "account_name", "account_key"); err != nil {
+		return nil, err
 	}
+	env := s.Data["environment"]
 	container := s.Data["container"]
-	if len(container) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "container")
-	}
 	name := s.Data["account_name"]
-	if len(name) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "account_name")
-	}
 	key := s.Data["account_key"]
-	if len(key) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "account_key")
-	}
 
 	return &storage.AzureStorageConfig{
 		Env:         string(env),
@@ -63,17 +60,11 @@ func extractAzureConfigSecret(s *corev1.Secret) (*storage.AzureStorageConfig, er
 }
 
 func extractGCSConfigSecret(s *corev1.Secret) (*storage.GCSStorageConfig, error) {
-	// Extract and validate mandatory fields
-	bucket := s.Data["bucketname"]
-	if len(bucket) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "bucketname")
-	}
-
-	// Check if google authentication credentials is provided
-	keyJSON := s.Data["key.json"]
-	if len(keyJSON) == 0 {
-		return nil, kverrors.New("missing google authentication credentials", "field", "key.json")
+	// Extract and validate mandatory fields, i.e. the bucket and the google authentication credentials
+	if err := requireSecretFields(s, lokiv1.ObjectStorageSecretGCS, "bucketname", "key.json"); err != nil {
+		return nil, err
 	}
+	bucket := s.Data["bucketname"]
 
 	return &storage.GCSStorageConfig{
 		Bucket: string(bucket),
@@ -82,74 +73,54 @@ func extractGCSConfigSecret(s *corev1.Secret) (*storage.GCSStorageConfig, error)
 
 func extractS3ConfigSecret(s *corev1.Secret) (*storage.S3StorageConfig, error) {
 	// Extract and validate mandatory fields
-	endpoint := s.Data["endpoint"]
-	if len(endpoint) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "endpoint")
-	}
-	buckets := s.Data["bucketnames"]
-	if len(buckets) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "bucketnames")
+	if err := requireSecretFields(s, lokiv1.ObjectStorageSecretS3, "endpoint", "bucketnames", "access_key_id", "access_key_secret"); err != nil {
+		return nil, err
 	}
+	endpoint := s.Data["endpoint"]
 	// TODO buckets are comma-separated list
+	buckets := s.Data["bucketnames"]
 	id := s.Data["access_key_id"]
-	if len(id) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "access_key_id")
-	}
 	secret := s.Data["access_key_secret"]
-	if len(secret) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "access_key_secret")
-	}
 
 	// Extract and validate optional fields
 	region := s.Data["region"]
 
+	var insecure bool
+	if v := s.Data["insecure"]; len(v) != 0 {
+		var err error
+		insecure, err = strconv.ParseBool(string(v))
+		if err != nil {
+			return nil, kverrors.Wrap(err, "invalid secret field", "field", "insecure")
+		}
+	}
+
 	return &storage.S3StorageConfig{
 		Endpoint:        string(endpoint),
 		Buckets:         string(buckets),
 		AccessKeyID:     string(id),
 		AccessKeySecret: string(secret),
 		Region:          string(region),
+		Insecure:        insecure,
 	}, nil
 }
 
 func extractSwiftConfigSecret(s *corev1.Secret) (*storage.SwiftStorageConfig, error) {
 	// Extract and validate mandatory fields
-	url := s.Data["auth_url"]
-	if len(url) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "auth_url")
+	if err := requireSecretFields(s, lokiv1.ObjectStorageSecretSwift,
+		"auth_url", "username", "user_domain_name", "user_domain_id", "user_id",
+		"password", "domain_id", "domain_name", "container_name",
+	); err != nil {
+		return nil, err
 	}
+	url := s.Data["auth_url"]
 	username := s.Data["username"]
-	if len(username) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "username")
-	}
 	userDomainName := s.Data["user_domain_name"]
-	if len(userDomainName) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "user_domain_name")
-	}
 	userDomainID := s.Data["user_domain_id"]
-	if len(userDomainID) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "user_domain_id")
-	}
 	userID := s.Data["user_id"]
-	if len(userID) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "user_id")
-	}
 	password := s.Data["password"]
-	if len(password) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "password")
-	}
 	domainID := s.Data["domain_id"]
-	if len(domainID) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "domain_id")
-	}
 	domainName := s.Data["domain_name"]
-	if len(domainName) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "domain_name")
-	}
 	containerName := s.Data["container_name"]
-	if len(containerName) == 0 {
-		return nil, kverrors.New("missing secret field", "field", "container_name")
-	}
 
 	// Extract and validate optional fields
 	projectID := s.Data["project_id"]
@@ -175,3 +146,26 @@ func extractSwiftConfigSecret(s *corev1.Secret) (*storage.SwiftStorageConfig, er
 		Container:         string(containerName),
 	}, nil
 }
+
+// requireSecretFields returns a degraded error naming the given mandatory fields of the object
+// storage type without a value in the secret. Secret values are never part of the error.
+func requireSecretFields(s *corev1.Secret, secretType lokiv1.ObjectStorageSecretType, fields ...string) error {
+	var missing []string
+	for _, field := range fields {
+		if len(s.Data[field]) == 0 {
+			missing = append(missing, field)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message: fmt.Sprintf("Object storage secret %s does not match storage type %s: missing keys %s",
+			s.Name, secretType, strings.Join(missing, ", ")),
+		Reason:         lokiv1.ReasonMismatchedObjectStorageSecret,
+		Requeue:        false,
+		InvolvedObject: status.SecretReference(s.Namespace, s.Name),
+	}
+}
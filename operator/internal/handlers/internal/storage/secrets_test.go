@@ -5,8 +5,10 @@ import (
 
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	"github.com/grafana/loki/operator/internal/handlers/internal/storage"
+	"github.com/grafana/loki/operator/internal/status"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAzureExtract(t *testing.T) {
@@ -168,6 +170,19 @@ func TestS3Extract(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid insecure",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					"endpoint":          []byte("here"),
+					"bucketnames":       []byte("this,that"),
+					"access_key_id":     []byte("id"),
+					"access_key_secret": []byte("secret"),
+					"insecure":          []byte("maybe"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "all set",
 			secret: &corev1.Secret{
@@ -179,6 +194,18 @@ func TestS3Extract(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "all set with insecure",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					"endpoint":          []byte("here"),
+					"bucketnames":       []byte("this,that"),
+					"access_key_id":     []byte("id"),
+					"access_key_secret": []byte("secret"),
+					"insecure":          []byte("true"),
+				},
+			},
+		},
 	}
 	for _, tst := range table {
 		tst := tst
@@ -340,3 +367,111 @@ func TestSwiftExtract(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractSecret_WhenMissingKeys_SetMismatched(t *testing.T) {
+	type test struct {
+		name        string
+		secretType  lokiv1.ObjectStorageSecretType
+		data        map[string][]byte
+		wantMissing string
+	}
+	table := []test{
+		{
+			name:       "azure complete",
+			secretType: lokiv1.ObjectStorageSecretAzure,
+			data: map[string][]byte{
+				"environment":  []byte("here"),
+				"container":    []byte("this,that"),
+				"account_name": []byte("id"),
+				"account_key":  []byte("secret"),
+			},
+		},
+		{
+			name:        "azure with s3 keys",
+			secretType:  lokiv1.ObjectStorageSecretAzure,
+			data:        map[string][]byte{"endpoint": []byte("here"), "account_key": []byte("secret")},
+			wantMissing: "environment, container, account_name",
+		},
+		{
+			name:       "gcs complete",
+			secretType: lokiv1.ObjectStorageSecretGCS,
+			data: map[string][]byte{
+				"bucketname": []byte("here"),
+				"key.json":   []byte("{\"type\": \"SA\"}"),
+			},
+		},
+		{
+			name:        "gcs with azure keys",
+			secretType:  lokiv1.ObjectStorageSecretGCS,
+			data:        map[string][]byte{"container": []byte("this,that")},
+			wantMissing: "bucketname, key.json",
+		},
+		{
+			name:       "s3 complete",
+			secretType: lokiv1.ObjectStorageSecretS3,
+			data: map[string][]byte{
+				"endpoint":          []byte("here"),
+				"bucketnames":       []byte("this,that"),
+				"access_key_id":     []byte("id"),
+				"access_key_secret": []byte("secret"),
+			},
+		},
+		{
+			name:        "s3 with gcs keys",
+			secretType:  lokiv1.ObjectStorageSecretS3,
+			data:        map[string][]byte{"bucketname": []byte("here"), "key.json": []byte("{}")},
+			wantMissing: "endpoint, bucketnames, access_key_id, access_key_secret",
+		},
+		{
+			name:       "swift complete",
+			secretType: lokiv1.ObjectStorageSecretSwift,
+			data: map[string][]byte{
+				"auth_url":         []byte("here"),
+				"username":         []byte("this,that"),
+				"user_domain_id":   []byte("id"),
+				"user_domain_name": []byte("name"),
+				"user_id":          []byte("id"),
+				"password":         []byte("secret"),
+				"domain_id":        []byte("id"),
+				"domain_name":      []byte("name"),
+				"container_name":   []byte("container"),
+			},
+		},
+		{
+			name:       "swift with s3 keys",
+			secretType: lokiv1.ObjectStorageSecretSwift,
+			data: map[string][]byte{
+				"endpoint": []byte("here"),
+				"password": []byte("secret"),
+			},
+			wantMissing: "auth_url, username, user_domain_name, user_domain_id, user_id, domain_id, domain_name, container_name",
+		},
+	}
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "some-ns"},
+				Data:       tst.data,
+			}
+
+			_, err := storage.ExtractSecret(s, tst.secretType)
+			if tst.wantMissing == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonMismatchedObjectStorageSecret, degraded.Reason)
+			require.Equal(t, "Object storage secret my-secret does not match storage type "+string(tst.secretType)+": missing keys "+tst.wantMissing, degraded.Message)
+		})
+	}
+}
+
+func TestExtractSecret_WhenUnknownType_ReturnError(t *testing.T) {
+	_, err := storage.ExtractSecret(&corev1.Secret{}, "unknown")
+	require.Error(t, err)
+}
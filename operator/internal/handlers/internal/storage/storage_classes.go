@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/status"
+
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateStorageClass checks that the StorageClass assigned to the volumes of all components
+// exists. Otherwise the volume claims stay unbound and the component pods pending without an
+// error on the LokiStack, thus a degraded error naming the StorageClass is returned. An empty
+// name refers to the default StorageClass of the cluster and is not checked. The check is
+// skipped if the operator is not permitted to read StorageClasses.
+func ValidateStorageClass(ctx context.Context, k k8s.Client, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	var sc storagev1.StorageClass
+	if err := k.Get(ctx, client.ObjectKey{Name: name}, &sc); err != nil {
+		if apierrors.IsForbidden(err) {
+			return nil
+		}
+		if apierrors.IsNotFound(err) {
+			return &status.DegradedError{
+				Message:     fmt.Sprintf("Missing StorageClass %s", name),
+				Reason:      lokiv1.ReasonMissingStorageClass,
+				Requeue:     true,
+				Remediation: "Create the StorageClass or set spec.storageClassName to an existing one",
+			}
+		}
+		return kverrors.Wrap(err, "failed to lookup storageclass", "name", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/handlers/internal/storage"
+	"github.com/grafana/loki/operator/internal/status"
+	"github.com/stretchr/testify/require"
+
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestValidateStorageClass(t *testing.T) {
+	table := []struct {
+		name    string
+		class   string
+		getErr  error
+		wantErr bool
+		wantMsg string
+	}{
+		{
+			name: "default storage class",
+		},
+		{
+			name:  "existing storage class",
+			class: "gp2",
+		},
+		{
+			name:    "missing storage class",
+			class:   "gp3",
+			getErr:  apierrors.NewNotFound(schema.GroupResource{}, "gp3"),
+			wantMsg: "Missing StorageClass gp3",
+		},
+		{
+			name:   "forbidden lookup",
+			class:  "gp2",
+			getErr: apierrors.NewForbidden(schema.GroupResource{}, "gp2", nil),
+		},
+		{
+			name:    "failed lookup",
+			class:   "gp2",
+			getErr:  apierrors.NewServiceUnavailable("unavailable"),
+			wantErr: true,
+		},
+	}
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			k := &k8sfakes.FakeClient{}
+			k.GetStub = func(_ context.Context, name client.ObjectKey, object client.Object, _ ...client.GetOption) error {
+				if tc.getErr != nil {
+					return tc.getErr
+				}
+				k.SetClientObject(object, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: name.Name}})
+				return nil
+			}
+
+			err := storage.ValidateStorageClass(context.TODO(), k, tc.class)
+
+			var degraded *status.DegradedError
+			switch {
+			case tc.wantErr:
+				require.Error(t, err)
+				require.False(t, errors.As(err, &degraded))
+			case tc.wantMsg != "":
+				require.ErrorAs(t, err, &degraded)
+				require.Equal(t, lokiv1.ReasonMissingStorageClass, degraded.Reason)
+				require.Equal(t, tc.wantMsg, degraded.Message)
+				require.True(t, degraded.Requeue)
+			default:
+				require.NoError(t, err)
+			}
+
+			if tc.class == "" {
+				require.Zero(t, k.GetCallCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package tlsprofile
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	openshiftconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/crypto"
+)
+
+// ValidateTLSSecurityProfile returns a degraded error naming the bad settings if the custom TLS
+// security profile specifies unsupported cipher suites or an invalid minimum TLS version. Cipher
+// suites are accepted by their OpenSSL or IANA names. A nil profile, the predefined profiles and
+// unset settings of a custom profile select the defaults and are valid.
+func ValidateTLSSecurityProfile(profile *openshiftconfigv1.TLSSecurityProfile) error {
+	if profile == nil || profile.Type != openshiftconfigv1.TLSProfileCustomType || profile.Custom == nil {
+		return nil
+	}
+
+	var (
+		spec   = profile.Custom.TLSProfileSpec
+		issues []string
+	)
+
+	if _, err := crypto.TLSVersion(string(spec.MinTLSVersion)); err != nil {
+		issues = append(issues, fmt.Sprintf("invalid minimum TLS version %q", spec.MinTLSVersion))
+	}
+
+	var unsupported []string
+	for _, c := range spec.Ciphers {
+		if len(crypto.OpenSSLToIANACipherSuites([]string{c})) != 0 {
+			continue
+		}
+		if isIANACipherSuite(c) {
+			continue
+		}
+		unsupported = append(unsupported, c)
+	}
+	if len(unsupported) != 0 {
+		issues = append(issues, fmt.Sprintf("unsupported cipher suites %s", strings.Join(unsupported, ", ")))
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	return &status.DegradedError{
+		Message:     fmt.Sprintf("Invalid TLS security profile: %s", strings.Join(issues, "; ")),
+		Reason:      lokiv1.ReasonInvalidTLSProfile,
+		Requeue:     true,
+		Remediation: "Use ciphers and a minimum TLS version supported by the operator in the custom TLS security profile",
+	}
+}
+
+// isIANACipherSuite returns true if name is the IANA name of a cipher suite implemented by Go,
+// including the TLS 1.3 suites which are always enabled.
+func isIANACipherSuite(name string) bool {
+	for _, suites := range [][]*tls.CipherSuite{tls.CipherSuites(), tls.InsecureCipherSuites()} {
+		for _, s := range suites {
+			if s.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
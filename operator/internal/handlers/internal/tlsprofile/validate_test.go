@@ -0,0 +1,82 @@
+package tlsprofile_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/handlers/internal/tlsprofile"
+	"github.com/grafana/loki/operator/internal/status"
+
+	openshiftconfigv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func customProfile(minVersion openshiftconfigv1.TLSProtocolVersion, ciphers ...string) *openshiftconfigv1.TLSSecurityProfile {
+	return &openshiftconfigv1.TLSSecurityProfile{
+		Type: openshiftconfigv1.TLSProfileCustomType,
+		Custom: &openshiftconfigv1.CustomTLSProfile{
+			TLSProfileSpec: openshiftconfigv1.TLSProfileSpec{
+				Ciphers:       ciphers,
+				MinTLSVersion: minVersion,
+			},
+		},
+	}
+}
+
+func TestValidateTLSSecurityProfile(t *testing.T) {
+	table := []struct {
+		name    string
+		profile *openshiftconfigv1.TLSSecurityProfile
+		wantMsg string
+	}{
+		{
+			name: "unset profile",
+		},
+		{
+			name:    "predefined profile",
+			profile: &openshiftconfigv1.TLSSecurityProfile{Type: openshiftconfigv1.TLSProfileModernType},
+		},
+		{
+			name:    "custom profile with defaults",
+			profile: customProfile(""),
+		},
+		{
+			name:    "valid custom profile",
+			profile: customProfile(openshiftconfigv1.VersionTLS12, "ECDHE-RSA-AES128-GCM-SHA256", "TLS_AES_128_GCM_SHA256"),
+		},
+		{
+			name:    "unsupported cipher",
+			profile: customProfile(openshiftconfigv1.VersionTLS12, "ECDHE-RSA-AES128-GCM-SHA256", "RC4-MD5", "NOT-A-CIPHER"),
+			wantMsg: "Invalid TLS security profile: unsupported cipher suites RC4-MD5, NOT-A-CIPHER",
+		},
+		{
+			name:    "bad min version",
+			profile: customProfile("VersionTLS14", "ECDHE-RSA-AES128-GCM-SHA256"),
+			wantMsg: `Invalid TLS security profile: invalid minimum TLS version "VersionTLS14"`,
+		},
+		{
+			name:    "bad min version and unsupported cipher",
+			profile: customProfile("TLSv1.2", "RC4-MD5"),
+			wantMsg: `Invalid TLS security profile: invalid minimum TLS version "TLSv1.2"; unsupported cipher suites RC4-MD5`,
+		},
+	}
+
+	for _, tst := range table {
+		tst := tst
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tlsprofile.ValidateTLSSecurityProfile(tst.profile)
+			if tst.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var degraded *status.DegradedError
+			require.ErrorAs(t, err, &degraded)
+			require.Equal(t, lokiv1.ReasonInvalidTLSProfile, degraded.Reason)
+			require.Equal(t, tst.wantMsg, degraded.Message)
+			require.True(t, degraded.Requeue)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -10,8 +11,11 @@ import (
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
 	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/handlers/internal/capacity"
 	"github.com/grafana/loki/operator/internal/handlers/internal/gateway"
+	"github.com/grafana/loki/operator/internal/handlers/internal/limits"
 	"github.com/grafana/loki/operator/internal/handlers/internal/openshift"
+	"github.com/grafana/loki/operator/internal/handlers/internal/rollout"
 	"github.com/grafana/loki/operator/internal/handlers/internal/rules"
 	"github.com/grafana/loki/operator/internal/handlers/internal/serviceaccounts"
 	"github.com/grafana/loki/operator/internal/handlers/internal/storage"
@@ -68,14 +72,36 @@ func CreateOrUpdateLokiStack(
 		gwImg = manifests.DefaultLokiStackGatewayImage
 	}
 
+	if err := capacity.ValidateClusterCapacity(ctx, k, stack.Spec); err != nil {
+		return err
+	}
+
+	if err := capacity.ValidateVolumeSizes(ctx, k, &stack); err != nil {
+		return err
+	}
+
+	if err := limits.Validate(stack.Spec.Limits); err != nil {
+		return err
+	}
+
+	if err := limits.ValidateIngestionRates(stack.Spec.Limits); err != nil {
+		return err
+	}
+
+	if err := limits.ValidateRetentionSelectors(stack.Spec.Limits); err != nil {
+		return err
+	}
+
 	var storageSecret corev1.Secret
 	key := client.ObjectKey{Name: stack.Spec.Storage.Secret.Name, Namespace: stack.Namespace}
 	if err := k.Get(ctx, key, &storageSecret); err != nil {
 		if apierrors.IsNotFound(err) {
 			return &status.DegradedError{
-				Message: "Missing object storage secret",
-				Reason:  lokiv1.ReasonMissingObjectStorageSecret,
-				Requeue: false,
+				Message:        "Missing object storage secret",
+				Reason:         lokiv1.ReasonMissingObjectStorageSecret,
+				Requeue:        false,
+				Remediation:    fmt.Sprintf("Create secret %s in namespace %s", key.Name, key.Namespace),
+				InvolvedObject: status.SecretReference(key.Namespace, key.Name),
 			}
 		}
 		return kverrors.Wrap(err, "failed to lookup lokistack storage secret", "name", key)
@@ -83,6 +109,10 @@ func CreateOrUpdateLokiStack(
 
 	objStore, err := storage.ExtractSecret(&storageSecret, stack.Spec.Storage.Secret.Type)
 	if err != nil {
+		var degraded *status.DegradedError
+		if errors.As(err, &degraded) {
+			return err
+		}
 		return &status.DegradedError{
 			Message: fmt.Sprintf("Invalid object storage secret contents: %s", err),
 			Reason:  lokiv1.ReasonInvalidObjectStorageSecret,
@@ -90,6 +120,12 @@ func CreateOrUpdateLokiStack(
 		}
 	}
 
+	if objStore.S3 != nil {
+		if err := storage.ValidateS3Endpoint(objStore.S3); err != nil {
+			return err
+		}
+	}
+
 	storageSchemas, err := storageoptions.BuildSchemaConfig(
 		time.Now().UTC(),
 		stack.Spec.Storage,
@@ -105,6 +141,10 @@ func CreateOrUpdateLokiStack(
 
 	objStore.Schemas = storageSchemas
 
+	if err := storage.ValidateStorageClass(ctx, k, stack.Spec.StorageClassName); err != nil {
+		return err
+	}
+
 	if stack.Spec.Storage.TLS != nil {
 		tlsConfig := stack.Spec.Storage.TLS
 
@@ -116,30 +156,14 @@ func CreateOrUpdateLokiStack(
 			}
 		}
 
-		var cm corev1.ConfigMap
-		key := client.ObjectKey{Name: tlsConfig.CA, Namespace: stack.Namespace}
-		if err = k.Get(ctx, key, &cm); err != nil {
-			if apierrors.IsNotFound(err) {
-				return &status.DegradedError{
-					Message: "Missing object storage CA config map",
-					Reason:  lokiv1.ReasonMissingObjectStorageCAConfigMap,
-					Requeue: false,
-				}
-			}
-			return kverrors.Wrap(err, "failed to lookup lokistack object storage CA config map", "name", key)
-		}
-
 		caKey := defaultCAKey
 		if tlsConfig.CAKey != "" {
 			caKey = tlsConfig.CAKey
 		}
 
-		if !storage.IsValidCAConfigMap(&cm, caKey) {
-			return &status.DegradedError{
-				Message: "Invalid object storage CA configmap contents: missing key or no contents",
-				Reason:  lokiv1.ReasonInvalidObjectStorageCAConfigMap,
-				Requeue: false,
-			}
+		cm, err := storage.GetCAConfigMap(ctx, k, stack.Namespace, tlsConfig.CA, caKey)
+		if err != nil {
+			return err
 		}
 
 		objStore.TLS = &storageoptions.TLSConfig{CA: cm.Name, Key: caKey}
@@ -157,6 +181,10 @@ func CreateOrUpdateLokiStack(
 			Requeue: false,
 		}
 	} else if fg.LokiStackGateway && stack.Spec.Tenants != nil {
+		if err = gateway.ValidateModeConflicts(stack.Spec.Tenants); err != nil {
+			return err
+		}
+
 		if err = gateway.ValidateModes(stack); err != nil {
 			return &status.DegradedError{
 				Message: fmt.Sprintf("Invalid tenants configuration: %s", err),
@@ -210,26 +238,26 @@ func CreateOrUpdateLokiStack(
 			ll.Error(err, "failed to lookup rules", "spec", stack.Spec.Rules)
 		}
 
+		if err := rules.ValidateExpressions(alertingRules, recordingRules); err != nil {
+			return err
+		}
+
 		rulerConfig, err = rules.GetRulerConfig(ctx, k, req)
 		if err != nil {
 			ll.Error(err, "failed to lookup ruler config", "key", req.NamespacedName)
 		}
 
+		if err := rules.ValidateExternalLabels(rulerConfig); err != nil {
+			return err
+		}
+
 		if rulerConfig != nil && rulerConfig.RemoteWriteSpec != nil && rulerConfig.RemoteWriteSpec.ClientSpec != nil {
-			var rs corev1.Secret
-			key := client.ObjectKey{Name: rulerConfig.RemoteWriteSpec.ClientSpec.AuthorizationSecretName, Namespace: stack.Namespace}
-			if err = k.Get(ctx, key, &rs); err != nil {
-				if apierrors.IsNotFound(err) {
-					return &status.DegradedError{
-						Message: "Missing ruler remote write authorization secret",
-						Reason:  lokiv1.ReasonMissingRulerSecret,
-						Requeue: false,
-					}
-				}
-				return kverrors.Wrap(err, "failed to lookup lokistack ruler secret", "name", key)
+			rs, err := rules.GetRemoteWriteSecret(ctx, k, stack.Namespace, rulerConfig.RemoteWriteSpec.ClientSpec.AuthorizationSecretName)
+			if err != nil {
+				return err
 			}
 
-			rulerSecret, err = rules.ExtractRulerSecret(&rs, rulerConfig.RemoteWriteSpec.ClientSpec.AuthorizationType)
+			rulerSecret, err = rules.ExtractRulerSecret(rs, rulerConfig.RemoteWriteSpec.ClientSpec.AuthorizationType)
 			if err != nil {
 				return &status.DegradedError{
 					Message: "Invalid ruler remote write authorization secret contents",
@@ -312,6 +340,10 @@ func CreateOrUpdateLokiStack(
 		ll.Error(err, "failed to get security profile. will use default tls profile.")
 	}
 
+	if err := tlsprofile.ValidateTLSSecurityProfile(tlsProfile); err != nil {
+		return err
+	}
+
 	if optErr := manifests.ApplyTLSSettings(&opts, tlsProfile); optErr != nil {
 		ll.Error(optErr, "failed to conform options to tls profile settings")
 		return optErr
@@ -387,7 +419,7 @@ func CreateOrUpdateLokiStack(
 		metrics.Collect(&opts.Stack, opts.Name)
 	}
 
-	return nil
+	return rollout.ValidatePodDisruptionBudgets(ctx, k, stack.Name, stack.Namespace)
 }
 
 func dependentAnnotations(ctx context.Context, k k8s.Client, obj client.Object) (map[string]string, error) {
@@ -695,9 +695,11 @@ func TestCreateOrUpdateLokiStack_WhenMissingSecret_SetDegraded(t *testing.T) {
 	}
 
 	degradedErr := &status.DegradedError{
-		Message: "Missing object storage secret",
-		Reason:  lokiv1.ReasonMissingObjectStorageSecret,
-		Requeue: false,
+		Message:        "Missing object storage secret",
+		Reason:         lokiv1.ReasonMissingObjectStorageSecret,
+		Requeue:        false,
+		Remediation:    "Create secret some-stack-secret in namespace some-ns",
+		InvolvedObject: status.SecretReference("some-ns", "some-stack-secret"),
 	}
 
 	stack := &lokiv1.LokiStack{
@@ -756,9 +758,10 @@ func TestCreateOrUpdateLokiStack_WhenInvalidSecret_SetDegraded(t *testing.T) {
 	}
 
 	degradedErr := &status.DegradedError{
-		Message: "Invalid object storage secret contents: missing secret field",
-		Reason:  lokiv1.ReasonInvalidObjectStorageSecret,
-		Requeue: false,
+		Message:        "Object storage secret some-stack-secret does not match storage type s3: missing keys endpoint, bucketnames, access_key_id, access_key_secret",
+		Reason:         lokiv1.ReasonMismatchedObjectStorageSecret,
+		Requeue:        false,
+		InvolvedObject: status.SecretReference("some-ns", "some-stack-secret"),
 	}
 
 	stack := &lokiv1.LokiStack{
@@ -895,9 +898,11 @@ func TestCreateOrUpdateLokiStack_WhenMissingCAConfigMap_SetDegraded(t *testing.T
 	}
 
 	degradedErr := &status.DegradedError{
-		Message: "Missing object storage CA config map",
-		Reason:  lokiv1.ReasonMissingObjectStorageCAConfigMap,
-		Requeue: false,
+		Message:        "Missing object storage CA config map: not-existing",
+		Reason:         lokiv1.ReasonMissingObjectStorageCAConfigMap,
+		Requeue:        true,
+		Remediation:    "Create config map not-existing in namespace some-ns",
+		InvolvedObject: status.ConfigMapReference("some-ns", "not-existing"),
 	}
 
 	stack := &lokiv1.LokiStack{
@@ -965,9 +970,10 @@ func TestCreateOrUpdateLokiStack_WhenInvalidCAConfigMap_SetDegraded(t *testing.T
 	}
 
 	degradedErr := &status.DegradedError{
-		Message: "Invalid object storage CA configmap contents: missing key or no contents",
-		Reason:  lokiv1.ReasonInvalidObjectStorageCAConfigMap,
-		Requeue: false,
+		Message:        "Invalid object storage CA configmap contents: missing key \"service-ca.crt\" or no contents in some-stack-ca-configmap",
+		Reason:         lokiv1.ReasonInvalidObjectStorageCAConfigMap,
+		Requeue:        true,
+		InvolvedObject: status.ConfigMapReference("some-ns", "some-stack-ca-configmap"),
 	}
 
 	stack := &lokiv1.LokiStack{
@@ -1122,9 +1128,11 @@ func TestCreateOrUpdateLokiStack_WhenMissingGatewaySecret_SetDegraded(t *testing
 	}
 
 	degradedErr := &status.DegradedError{
-		Message: "Missing secrets for tenant test",
-		Reason:  lokiv1.ReasonMissingGatewayTenantSecret,
-		Requeue: true,
+		Message:        "Missing secrets for tenant test",
+		Reason:         lokiv1.ReasonMissingGatewayTenantSecret,
+		Requeue:        true,
+		Remediation:    "Create secret some-stack-gateway-secret in namespace some-ns",
+		InvolvedObject: status.SecretReference("some-ns", "some-stack-gateway-secret"),
 	}
 
 	ff := configv1.FeatureGates{
@@ -0,0 +1,84 @@
+package manifests
+
+import (
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/imdario/mergo"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests/internal"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ComponentRequests defines the resource requests of a single pod of a LokiStack
+// component and the number of pods deployed for it.
+type ComponentRequests struct {
+	Name     string
+	Replicas int32
+	Requests corev1.ResourceList
+}
+
+// StackRequests returns the resource requests per component deployed for the given
+// LokiStack spec. Replicas are taken from the spec template and fall back to the
+// defaults of the spec size. The ruler and the gateway are only included if enabled.
+func StackRequests(stack lokiv1.LokiStackSpec) ([]ComponentRequests, error) {
+	resources, ok := internal.ResourceRequirementsTable[stack.Size]
+	if !ok {
+		return nil, kverrors.New("unknown lokistack size", "size", stack.Size)
+	}
+
+	spec := DefaultLokiStackSpec(stack.Size)
+	if err := mergo.Merge(spec, stack, mergo.WithOverride); err != nil {
+		return nil, kverrors.Wrap(err, "failed merging stack user options")
+	}
+
+	tpl := spec.Template
+	res := []ComponentRequests{
+		{Name: LabelCompactorComponent, Replicas: 1, Requests: resources.Compactor.Requests},
+		{Name: LabelDistributorComponent, Replicas: replicas(tpl.Distributor), Requests: resources.Distributor.Requests},
+		{Name: LabelIngesterComponent, Replicas: replicas(tpl.Ingester), Requests: resources.Ingester.Requests},
+		{Name: LabelQuerierComponent, Replicas: replicas(tpl.Querier), Requests: resources.Querier.Requests},
+		{Name: LabelQueryFrontendComponent, Replicas: replicas(tpl.QueryFrontend), Requests: resources.QueryFrontend.Requests},
+		{Name: LabelIndexGatewayComponent, Replicas: replicas(tpl.IndexGateway), Requests: resources.IndexGateway.Requests},
+	}
+
+	if spec.Rules != nil && spec.Rules.Enabled {
+		res = append(res, ComponentRequests{Name: LabelRulerComponent, Replicas: replicas(tpl.Ruler), Requests: resources.Ruler.Requests})
+	}
+
+	if spec.Tenants != nil {
+		res = append(res, ComponentRequests{Name: LabelGatewayComponent, Replicas: replicas(tpl.Gateway), Requests: resources.Gateway.Requests})
+	}
+
+	return res, nil
+}
+
+func replicas(spec *lokiv1.LokiComponentSpec) int32 {
+	if spec == nil {
+		return 0
+	}
+	return spec.Replicas
+}
+
+// StackVolumeSizes returns the persistent volume claim sizes of the given LokiStack size keyed by
+// component and volume claim template name, e.g. "wal" for the ingester write ahead log.
+func StackVolumeSizes(size lokiv1.LokiStackSizeType) (map[string]map[string]resource.Quantity, error) {
+	resources, ok := internal.ResourceRequirementsTable[size]
+	if !ok {
+		return nil, kverrors.New("unknown lokistack size", "size", size)
+	}
+
+	return map[string]map[string]resource.Quantity{
+		LabelCompactorComponent:    {storageVolumeName: resources.Compactor.PVCSize},
+		LabelIndexGatewayComponent: {storageVolumeName: resources.IndexGateway.PVCSize},
+		LabelIngesterComponent: {
+			storageVolumeName: resources.Ingester.PVCSize,
+			walVolumeName:     resources.WALStorage.PVCSize,
+		},
+		LabelRulerComponent: {
+			storageVolumeName: resources.Ruler.PVCSize,
+			walVolumeName:     resources.WALStorage.PVCSize,
+		},
+	}, nil
+}
@@ -39,6 +39,7 @@ type S3StorageConfig struct {
 	Buckets         string
 	AccessKeyID     string
 	AccessKeySecret string
+	Insecure        bool
 }
 
 // SwiftStorageConfig for Swift storage config
@@ -129,6 +129,11 @@ func serviceAnnotations(serviceName string, enableSigningService bool) map[strin
 	return annotations
 }
 
+// StackLabels is a list of labels assigned to all resources of the LokiStack
+func StackLabels(stackName string) labels.Set {
+	return commonLabels(stackName)
+}
+
 // ComponentLabels is a list of all commonLabels including the app.kubernetes.io/component:<component> label
 func ComponentLabels(component, stackName string) labels.Set {
 	return labels.Merge(commonLabels(stackName), map[string]string{
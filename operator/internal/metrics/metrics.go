@@ -2,8 +2,10 @@ package metrics
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
@@ -19,6 +21,18 @@ const (
 	labelTenant UserDefinedLimitsType = "tenant"
 )
 
+// StatusUpdateResult defines the label value describing the outcome of a status update.
+type StatusUpdateResult string
+
+const (
+	// StatusUpdateSuccess when the status was written.
+	StatusUpdateSuccess StatusUpdateResult = "success"
+	// StatusUpdateConflict when the status write still conflicted after all retries.
+	StatusUpdateConflict StatusUpdateResult = "conflict"
+	// StatusUpdateError when the status write failed for any other reason.
+	StatusUpdateError StatusUpdateResult = "error"
+)
+
 var (
 	deploymentMetric = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -51,6 +65,38 @@ var (
 		},
 		[]string{"size", "stack_id"},
 	)
+
+	statusNoopMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "lokistack_status_noop_total",
+			Help: "Number of status updates skipped because the LokiStack already had the desired conditions",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	statusUpdateDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "lokistack_status_update_duration_seconds",
+			Help:    "Duration of LokiStack status updates including conflict retries",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"result"},
+	)
+
+	conditionChangesDroppedMetric = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "lokistack_condition_changes_dropped_total",
+			Help: "Number of LokiStack condition changes dropped because a subscriber buffer was full",
+		},
+	)
+
+	conditionTransitionsMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "lokistack_condition_transitions",
+			Help: "Number of LokiStack condition transitions within the flapping detection window",
+		},
+		[]string{"namespace", "name"},
+	)
 )
 
 // RegisterMetricCollectors registers the prometheus collectors with the k8 default metrics
@@ -60,6 +106,10 @@ func RegisterMetricCollectors() {
 		userDefinedLimitsMetric,
 		globalStreamLimitMetric,
 		averageTenantStreamLimitMetric,
+		statusNoopMetric,
+		statusUpdateDurationMetric,
+		conditionChangesDroppedMetric,
+		conditionTransitionsMetric,
 	}
 
 	for _, collector := range metricCollectors {
@@ -106,6 +156,44 @@ func Collect(spec *lokiv1.LokiStackSpec, stackName string) {
 	}
 }
 
+// IncStatusNoop counts a status update of a LokiStack that was skipped
+// because the status already matched the desired state.
+func IncStatusNoop(stack types.NamespacedName) {
+	statusNoopMetric.With(prometheus.Labels{
+		"namespace": stack.Namespace,
+		"name":      stack.Name,
+	}).Inc()
+}
+
+// ObserveStatusUpdateDuration records the duration of a LokiStack status update by its result.
+func ObserveStatusUpdateDuration(result StatusUpdateResult, d time.Duration) {
+	statusUpdateDurationMetric.With(prometheus.Labels{
+		"result": string(result),
+	}).Observe(d.Seconds())
+}
+
+// SetConditionTransitions records the number of condition transitions of a LokiStack within
+// the flapping detection window.
+func SetConditionTransitions(stack types.NamespacedName, count int) {
+	conditionTransitionsMetric.With(prometheus.Labels{
+		"namespace": stack.Namespace,
+		"name":      stack.Name,
+	}).Set(float64(count))
+}
+
+// DeleteConditionTransitions removes the condition transitions recorded for a LokiStack.
+func DeleteConditionTransitions(stack types.NamespacedName) {
+	conditionTransitionsMetric.Delete(prometheus.Labels{
+		"namespace": stack.Namespace,
+		"name":      stack.Name,
+	})
+}
+
+// IncConditionChangesDropped counts a LokiStack condition change dropped for a subscriber.
+func IncConditionChangesDropped() {
+	conditionChangesDroppedMetric.Inc()
+}
+
 func setDeploymentMetric(size lokiv1.LokiStackSizeType, identifier string, active bool) {
 	deploymentMetric.With(prometheus.Labels{
 		"size":     string(size),
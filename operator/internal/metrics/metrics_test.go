@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestIncStatusNoop(t *testing.T) {
+	statusNoopMetric.Reset()
+	t.Cleanup(statusNoopMetric.Reset)
+
+	IncStatusNoop(types.NamespacedName{Namespace: "some-ns", Name: "my-stack"})
+	IncStatusNoop(types.NamespacedName{Namespace: "some-ns", Name: "my-stack"})
+	IncStatusNoop(types.NamespacedName{Namespace: "other-ns", Name: "my-stack"})
+
+	require.Equal(t, float64(2), testutil.ToFloat64(statusNoopMetric.WithLabelValues("some-ns", "my-stack")))
+	require.Equal(t, float64(1), testutil.ToFloat64(statusNoopMetric.WithLabelValues("other-ns", "my-stack")))
+}
+
+func TestSetConditionTransitions(t *testing.T) {
+	conditionTransitionsMetric.Reset()
+	t.Cleanup(conditionTransitionsMetric.Reset)
+
+	SetConditionTransitions(types.NamespacedName{Namespace: "some-ns", Name: "my-stack"}, 3)
+	SetConditionTransitions(types.NamespacedName{Namespace: "some-ns", Name: "my-stack"}, 1)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(conditionTransitionsMetric.WithLabelValues("some-ns", "my-stack")))
+
+	DeleteConditionTransitions(types.NamespacedName{Namespace: "some-ns", Name: "my-stack"})
+	require.Zero(t, testutil.CollectAndCount(conditionTransitionsMetric))
+}
+
+func TestObserveStatusUpdateDuration(t *testing.T) {
+	statusUpdateDurationMetric.Reset()
+	t.Cleanup(statusUpdateDurationMetric.Reset)
+
+	ObserveStatusUpdateDuration(StatusUpdateSuccess, 100*time.Millisecond)
+	ObserveStatusUpdateDuration(StatusUpdateSuccess, 200*time.Millisecond)
+	ObserveStatusUpdateDuration(StatusUpdateConflict, time.Second)
+
+	expected := `
+# HELP lokistack_status_update_duration_seconds Duration of LokiStack status updates including conflict retries
+# TYPE lokistack_status_update_duration_seconds histogram
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.005"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.01"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.025"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.05"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.1"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.25"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="0.5"} 0
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="1"} 1
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="2.5"} 1
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="5"} 1
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="10"} 1
+lokistack_status_update_duration_seconds_bucket{result="conflict",le="+Inf"} 1
+lokistack_status_update_duration_seconds_sum{result="conflict"} 1
+lokistack_status_update_duration_seconds_count{result="conflict"} 1
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.005"} 0
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.01"} 0
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.025"} 0
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.05"} 0
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.1"} 1
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.25"} 2
+lokistack_status_update_duration_seconds_bucket{result="success",le="0.5"} 2
+lokistack_status_update_duration_seconds_bucket{result="success",le="1"} 2
+lokistack_status_update_duration_seconds_bucket{result="success",le="2.5"} 2
+lokistack_status_update_duration_seconds_bucket{result="success",le="5"} 2
+lokistack_status_update_duration_seconds_bucket{result="success",le="10"} 2
+lokistack_status_update_duration_seconds_bucket{result="success",le="+Inf"} 2
+lokistack_status_update_duration_seconds_sum{result="success"} 0.30000000000000004
+lokistack_status_update_duration_seconds_count{result="success"} 2
+`
+	err := testutil.CollectAndCompare(statusUpdateDurationMetric, strings.NewReader(expected))
+	require.NoError(t, err)
+}
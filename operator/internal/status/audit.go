@@ -0,0 +1,45 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/loki/operator/internal/version"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// auditMessage is the stable message of all condition transition audit log lines.
+const auditMessage = "lokistack condition transition"
+
+// auditTransitions emits an audit log line for every condition in written that is new or differs
+// in status, reason or message from the condition of the same type in previous. The logger is
+// taken from the context, thus the lines carry the reconciling controller and reconcile ID.
+func auditTransitions(ctx context.Context, req ctrl.Request, previous, written []metav1.Condition) {
+	if !options.AuditConditionTransitions {
+		return
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	for _, change := range conditionChanges(req.NamespacedName, previous, written) {
+		var p metav1.Condition
+		if change.Previous != nil {
+			p = *change.Previous
+		}
+		c := change.Current
+
+		log.Info(auditMessage,
+			"audit", true,
+			"lokistack", req.NamespacedName.String(),
+			"operatorVersion", version.Version,
+			"type", c.Type,
+			"status", c.Status,
+			"previousStatus", p.Status,
+			"reason", c.Reason,
+			"previousReason", p.Reason,
+			"message", c.Message,
+			"transitionTime", c.LastTransitionTime.UTC().Format(time.RFC3339),
+		)
+	}
+}
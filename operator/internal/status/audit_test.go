@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestSetReadyCondition_AuditTransitions(t *testing.T) {
+	pending := metav1.Condition{
+		Type:    string(lokiv1.ConditionPending),
+		Reason:  string(lokiv1.ReasonPendingComponents),
+		Message: messagePending,
+		Status:  metav1.ConditionTrue,
+	}
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name       string
+		audit      bool
+		conditions []metav1.Condition
+		wantLines  []string
+	}{
+		{
+			name:       "audit disabled",
+			conditions: []metav1.Condition{pending},
+		},
+		{
+			name:       "transition",
+			audit:      true,
+			conditions: []metav1.Condition{pending},
+			wantLines: []string{
+				`"level"=0 "msg"="lokistack condition transition" "audit"=true "lokistack"="some-ns/my-stack" "operatorVersion"="unknown" "type"="Pending" "status"="False" "previousStatus"="True" "reason"="PendingComponents" "previousReason"="PendingComponents" "message"="Some LokiStack components pending on dependencies" "transitionTime"="2022-10-11T12:00:00Z"`,
+				`"level"=0 "msg"="lokistack condition transition" "audit"=true "lokistack"="some-ns/my-stack" "operatorVersion"="unknown" "type"="Ready" "status"="True" "previousStatus"="" "reason"="ReadyComponents" "previousReason"="" "message"="All components ready" "transitionTime"="2022-10-11T12:00:00Z"`,
+			},
+		},
+		{
+			name:       "no-op",
+			audit:      true,
+			conditions: []metav1.Condition{ready},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.AuditConditionTransitions = tc.audit
+			opts.Clock = clocktesting.NewFakePassiveClock(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+			Configure(opts)
+			t.Cleanup(func() { Configure(DefaultOptions()) })
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			var lines []string
+			logger := funcr.New(func(_, args string) { lines = append(lines, args) }, funcr.Options{})
+			ctx := ctrl.LoggerInto(context.Background(), logger)
+
+			k, _ := setupFakes(&s)
+
+			err := SetReadyCondition(ctx, k, r)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantLines, lines)
+		})
+	}
+}
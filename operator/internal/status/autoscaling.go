@@ -0,0 +1,29 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetAutoscalingCondition reports whether the horizontal pod autoscaler of a component is pinned at
+// its maximum replicas. A component at max sets the condition Warning alongside the other conditions,
+// since the autoscaler cannot add capacity anymore. A component within range clears a warning
+// previously reported for the same component only.
+func SetAutoscalingCondition(ctx context.Context, k k8s.Client, req ctrl.Request, atMax bool, component string) error {
+	msg := fmt.Sprintf("Autoscaler of component %s is pinned at its maximum replicas", component)
+
+	if atMax {
+		return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+			Reason:    lokiv1.ReasonAutoscalingAtMaxReplicas,
+			Component: component,
+			Message:   msg,
+		})
+	}
+
+	return clearWarning(ctx, k, req, lokiv1.ReasonAutoscalingAtMaxReplicas, component)
+}
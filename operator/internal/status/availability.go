@@ -0,0 +1,144 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// componentWorkloads maps the LokiStack components to their workload kind and name.
+var componentWorkloads = map[string]struct {
+	statefulSet bool
+	name        func(stackName string) string
+}{
+	manifests.LabelCompactorComponent:     {statefulSet: true, name: manifests.CompactorName},
+	manifests.LabelDistributorComponent:   {name: manifests.DistributorName},
+	manifests.LabelIngesterComponent:      {statefulSet: true, name: manifests.IngesterName},
+	manifests.LabelQuerierComponent:       {name: manifests.QuerierName},
+	manifests.LabelQueryFrontendComponent: {name: manifests.QueryFrontendName},
+	manifests.LabelIndexGatewayComponent:  {statefulSet: true, name: manifests.IndexGatewayName},
+	manifests.LabelGatewayComponent:       {name: manifests.GatewayName},
+	manifests.LabelRulerComponent:         {statefulSet: true, name: manifests.RulerName},
+}
+
+// setPartiallyAvailableCondition sets the condition PartiallyAvailable alongside the other conditions
+// if some but not all desired component pods are running, e.g. 2 of 3 querier pods. The desired pods
+// of a component are the spec.replicas of its Deployment or StatefulSet, thus pods not created yet
+// count as unavailable. Components without a workload fall back to the pods that exist. The message
+// shows the ratio of running pods in total and for each component below its desired pods. The
+// condition is cleared once all or no pods are running, i.e. the LokiStack is either fully available
+// or fully down, and by SetReadyCondition. The distributor of a read-only LokiStack is ignored like
+// in Refresh.
+func setPartiallyAvailableCondition(ctx context.Context, k k8s.Client, req ctrl.Request, stack *lokiv1.LokiStack, cs lokiv1.LokiStackComponentStatus) error {
+	var (
+		running, total int
+		partial        = map[string]string{}
+	)
+	for component, psm := range componentPodStatus(cs) {
+		if component == manifests.LabelDistributorComponent && isReadOnly(stack) {
+			continue
+		}
+
+		var r, t int
+		for phase, pods := range psm {
+			t += len(pods)
+			if phase == corev1.PodRunning {
+				r += len(pods)
+			}
+		}
+
+		desired, ok, err := desiredReplicas(ctx, k, stack, component)
+		if err != nil {
+			return err
+		}
+		if ok {
+			t = desired
+		}
+		if r > t {
+			// e.g. surge pods of a rolling update
+			r = t
+		}
+
+		if r < t {
+			partial[component] = fmt.Sprintf("%d/%d", r, t)
+		}
+		running += r
+		total += t
+	}
+
+	if running == 0 || running == total {
+		return clearCondition(ctx, k, req, lokiv1.ConditionPartiallyAvailable, lokiv1.ReasonPartialAvailability)
+	}
+
+	return updateCondition(ctx, k, req, metav1.Condition{
+		Type:    string(lokiv1.ConditionPartiallyAvailable),
+		Message: fmt.Sprintf("%d/%d pods running: %s", running, total, FormatMap(partial, "%s %s")),
+		Reason:  string(lokiv1.ReasonPartialAvailability),
+	}, CoexistPolicy)
+}
+
+// resetPartiallyAvailable sets an active condition PartiallyAvailable to false unless it is
+// pinned and reports whether it changed.
+func resetPartiallyAvailable(stack *lokiv1.LokiStack) bool {
+	for i, c := range stack.Status.Conditions {
+		if c.Type != string(lokiv1.ConditionPartiallyAvailable) || c.Status != metav1.ConditionTrue {
+			continue
+		}
+		if isPinned(stack, c.Type, c.Reason) {
+			return false
+		}
+
+		stack.Status.Conditions[i].Status = metav1.ConditionFalse
+		stack.Status.Conditions[i].LastTransitionTime = metav1.NewTime(now())
+		return true
+	}
+
+	return false
+}
+
+// desiredReplicas returns the spec.replicas of the workload of the component. It returns
+// false if the workload does not exist, e.g. the ruler of a LokiStack without rules.
+func desiredReplicas(ctx context.Context, k k8s.Client, stack *lokiv1.LokiStack, component string) (int, bool, error) {
+	w, ok := componentWorkloads[component]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var (
+		obj      client.Object
+		replicas func() *int32
+	)
+	if w.statefulSet {
+		s := &appsv1.StatefulSet{}
+		obj, replicas = s, func() *int32 { return s.Spec.Replicas }
+	} else {
+		d := &appsv1.Deployment{}
+		obj, replicas = d, func() *int32 { return d.Spec.Replicas }
+	}
+
+	key := types.NamespacedName{Name: w.name(stack.Name), Namespace: stack.Namespace}
+	if err := k.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, kverrors.Wrap(err, "failed to lookup LokiStack component workload", "name", key, "component", component)
+	}
+
+	if r := replicas(); r != nil {
+		return int(*r), true, nil
+	}
+
+	return 1, true, nil
+}
@@ -0,0 +1,313 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetPartiallyAvailableCondition(t *testing.T) {
+	partial := metav1.Condition{
+		Type:    string(lokiv1.ConditionPartiallyAvailable),
+		Reason:  string(lokiv1.ReasonPartialAvailability),
+		Message: "4/5 pods running: querier 2/3",
+		Status:  metav1.ConditionTrue,
+	}
+
+	querier := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      manifests.QuerierName("my-stack"),
+			Namespace: "some-ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(3),
+		},
+	}
+
+	table := []struct {
+		name        string
+		components  lokiv1.LokiStackComponentStatus
+		workloads   []client.Object
+		conditions  []metav1.Condition
+		wantUpdate  bool
+		wantPartial metav1.ConditionStatus
+	}{
+		{
+			name: "full availability",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}},
+				Querier:  lokiv1.PodStatusMap{corev1.PodRunning: {"querier-0", "querier-1", "querier-2"}},
+			},
+		},
+		{
+			name: "partial availability",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}},
+				Querier: lokiv1.PodStatusMap{
+					corev1.PodRunning: {"querier-0", "querier-1"},
+					corev1.PodPending: {"querier-2"},
+				},
+			},
+			wantUpdate:  true,
+			wantPartial: metav1.ConditionTrue,
+		},
+		{
+			name: "missing pods of desired replicas",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}},
+				Querier:  lokiv1.PodStatusMap{corev1.PodRunning: {"querier-0", "querier-1"}},
+			},
+			workloads:   []client.Object{querier},
+			wantUpdate:  true,
+			wantPartial: metav1.ConditionTrue,
+		},
+		{
+			name: "surge pods of desired replicas",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}},
+				Querier:  lokiv1.PodStatusMap{corev1.PodRunning: {"querier-0", "querier-1", "querier-2", "querier-3"}},
+			},
+			workloads: []client.Object{querier},
+		},
+		{
+			name: "still partial availability",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}},
+				Querier: lokiv1.PodStatusMap{
+					corev1.PodRunning: {"querier-0", "querier-1"},
+					corev1.PodFailed:  {"querier-2"},
+				},
+			},
+			conditions: []metav1.Condition{partial},
+		},
+		{
+			name: "zero availability",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodPending: {"ingester-0", "ingester-1"}},
+				Querier:  lokiv1.PodStatusMap{corev1.PodFailed: {"querier-0", "querier-1", "querier-2"}},
+			},
+			conditions:  []metav1.Condition{partial},
+			wantUpdate:  true,
+			wantPartial: metav1.ConditionFalse,
+		},
+		{
+			name: "full availability after partial availability",
+			components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}},
+				Querier:  lokiv1.PodStatusMap{corev1.PodRunning: {"querier-0", "querier-1", "querier-2"}},
+			},
+			conditions:  []metav1.Condition{partial},
+			wantUpdate:  true,
+			wantPartial: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+				if name == r.NamespacedName {
+					k.SetClientObject(object, &s)
+					return nil
+				}
+				for _, w := range tc.workloads {
+					if name.Name == w.GetName() {
+						k.SetClientObject(object, w)
+						return nil
+					}
+				}
+				return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+			}
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				condition := ConditionsMap(obj.(*lokiv1.LokiStack))[partial.Type]
+				require.Equal(t, tc.wantPartial, condition.Status)
+				require.Equal(t, partial.Reason, condition.Reason)
+				require.Equal(t, partial.Message, condition.Message)
+				return nil
+			}
+
+			err := setPartiallyAvailableCondition(context.Background(), k, r, &s, tc.components)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestRefresh_WhenPartiallyAvailable_CoexistWithPending(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	k.ListStub = func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+		lo := &client.ListOptions{}
+		lo.ApplyOptions(opts)
+
+		pods := []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		}
+
+		querier := labels.Set(manifests.ComponentLabels(manifests.LabelQuerierComponent, s.Name))
+		if lo.LabelSelector.Matches(querier) {
+			pods = []corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "querier-0"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "querier-1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "querier-2"}, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+			}
+		}
+
+		k.SetClientObjectList(l, &corev1.PodList{Items: pods})
+		return nil
+	}
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := Refresh(context.Background(), k, r)
+	require.NoError(t, err)
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionPending)].Status)
+	require.NotEqual(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+
+	partial := conditions[string(lokiv1.ConditionPartiallyAvailable)]
+	require.Equal(t, metav1.ConditionTrue, partial.Status)
+	require.Equal(t, string(lokiv1.ReasonPartialAvailability), partial.Reason)
+	require.Regexp(t, `^\d+/\d+ pods running: querier 2/3$`, partial.Message)
+}
+
+func TestRefresh_WhenPartiallyAvailable_ClearOnReady(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	querier := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      manifests.QuerierName("my-stack"),
+			Namespace: "some-ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(4),
+		},
+	}
+
+	querierPhase := corev1.PodPending
+
+	k, sw := setupFakes(&s)
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		if name == r.NamespacedName {
+			k.SetClientObject(object, &s)
+			return nil
+		}
+		if _, ok := object.(*appsv1.Deployment); ok && name.Name == querier.Name {
+			k.SetClientObject(object, querier)
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+	k.ListStub = func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+		lo := &client.ListOptions{}
+		lo.ApplyOptions(opts)
+
+		pods := []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		}
+
+		querierLabels := labels.Set(manifests.ComponentLabels(manifests.LabelQuerierComponent, s.Name))
+		if lo.LabelSelector.Matches(querierLabels) {
+			pods = []corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "querier-0"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "querier-1"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "querier-2"}, Status: corev1.PodStatus{Phase: querierPhase}},
+			}
+		}
+
+		k.SetClientObjectList(l, &corev1.PodList{Items: pods})
+		return nil
+	}
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	require.NoError(t, Refresh(context.Background(), k, r))
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(&s)[string(lokiv1.ConditionPartiallyAvailable)].Status)
+
+	// All pods running, the missing pod of the desired replicas does not keep PartiallyAvailable
+	querierPhase = corev1.PodRunning
+	require.NoError(t, Refresh(context.Background(), k, r))
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionPartiallyAvailable)].Status)
+
+	// Further refreshes do not set PartiallyAvailable again
+	writes := sw.UpdateCallCount()
+	require.NoError(t, Refresh(context.Background(), k, r))
+	require.Equal(t, writes, sw.UpdateCallCount())
+}
@@ -0,0 +1,28 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetBlockedQueriesCondition sets the condition BlockedQueries alongside the other conditions if
+// a blocked queries policy with the given number of patterns is in effect and clears it otherwise.
+// Queries rejected for matching a blocked pattern are expected and thus never make the LokiStack
+// Degraded, i.e. the condition Ready and the component conditions are left untouched.
+func SetBlockedQueriesCondition(ctx context.Context, k k8s.Client, req ctrl.Request, patterns int) error {
+	if patterns <= 0 {
+		return clearCondition(ctx, k, req, lokiv1.ConditionBlockedQueries, lokiv1.ReasonBlockedQueriesPolicy)
+	}
+
+	return updateCondition(ctx, k, req, metav1.Condition{
+		Type:    string(lokiv1.ConditionBlockedQueries),
+		Message: fmt.Sprintf("Blocked queries policy is in effect, queries matching %d pattern(s) are rejected", patterns),
+		Reason:  string(lokiv1.ReasonBlockedQueriesPolicy),
+	}, CoexistPolicy)
+}
@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetBlockedQueriesCondition(t *testing.T) {
+	blocked := metav1.Condition{
+		Type:    string(lokiv1.ConditionBlockedQueries),
+		Reason:  string(lokiv1.ReasonBlockedQueriesPolicy),
+		Message: "Blocked queries policy is in effect, queries matching 2 pattern(s) are rejected",
+		Status:  metav1.ConditionTrue,
+	}
+
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name        string
+		patterns    int
+		conditions  []metav1.Condition
+		wantUpdate  bool
+		wantBlocked metav1.ConditionStatus
+	}{
+		{
+			name:        "enable blocked queries policy",
+			patterns:    2,
+			conditions:  []metav1.Condition{ready},
+			wantUpdate:  true,
+			wantBlocked: metav1.ConditionTrue,
+		},
+		{
+			name:       "already blocking queries",
+			patterns:   2,
+			conditions: []metav1.Condition{ready, blocked},
+		},
+		{
+			name:        "change blocked patterns",
+			patterns:    3,
+			conditions:  []metav1.Condition{ready, blocked},
+			wantUpdate:  true,
+			wantBlocked: metav1.ConditionTrue,
+		},
+		{
+			name:        "disable blocked queries policy",
+			conditions:  []metav1.Condition{ready, blocked},
+			wantUpdate:  true,
+			wantBlocked: metav1.ConditionFalse,
+		},
+		{
+			name:       "never blocking queries",
+			conditions: []metav1.Condition{ready},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+				require.Equal(t, tc.wantBlocked, conditions[string(lokiv1.ConditionBlockedQueries)].Status)
+				require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+				require.NotContains(t, conditions, string(lokiv1.ConditionDegraded))
+				return nil
+			}
+
+			err := SetBlockedQueriesCondition(context.Background(), k, r, tc.patterns)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestRefresh_WhenBlockingQueries_NotDegraded(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	k.ListStub = func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+		k.SetClientObjectList(l, &corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+					Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+				},
+			},
+		})
+		return nil
+	}
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := SetBlockedQueriesCondition(context.Background(), k, r, 1)
+	require.NoError(t, err)
+
+	// Queries are rejected by the policy while all query path pods keep running
+	err = Refresh(context.Background(), k, r)
+	require.NoError(t, err)
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionBlockedQueries)].Status)
+	require.NotEqual(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionDegraded)].Status)
+}
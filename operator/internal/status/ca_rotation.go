@@ -0,0 +1,37 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetCARotationCondition reports the progress of a signing CA rotation. While components still
+// trust only the previous CA the condition Warning is set naming them. If the rotation cannot
+// complete because of these components, i.e. blocked is true, the condition Degraded is set
+// instead. Without stale components a previously reported rotation warning is cleared.
+func SetCARotationCondition(ctx context.Context, k k8s.Client, req ctrl.Request, staleComponents []string, blocked bool) error {
+	if len(staleComponents) == 0 {
+		return clearWarning(ctx, k, req, lokiv1.ReasonCARotationInProgress, "")
+	}
+
+	components := append([]string{}, staleComponents...)
+	sort.Strings(components)
+	names := strings.Join(components, ", ")
+
+	if blocked {
+		msg := fmt.Sprintf("Signing CA rotation blocked by components not trusting the new CA: %s", names)
+		return SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonCARotationBlocked)
+	}
+
+	return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+		Reason:  lokiv1.ReasonCARotationInProgress,
+		Message: fmt.Sprintf("Signing CA rotation in progress, components pending the new CA: %s", names),
+	})
+}
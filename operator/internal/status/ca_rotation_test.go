@@ -0,0 +1,116 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetCARotationCondition(t *testing.T) {
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	inProgress := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonCARotationInProgress),
+		Message: "Signing CA rotation in progress, components pending the new CA: ingester, querier",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name       string
+		stale      []string
+		blocked    bool
+		conditions []metav1.Condition
+		wantUpdate bool
+		want       metav1.Condition
+	}{
+		{
+			name:       "rotation in progress",
+			stale:      []string{"querier", "ingester"},
+			conditions: []metav1.Condition{ready},
+			wantUpdate: true,
+			want:       inProgress,
+		},
+		{
+			name:       "rotation blocked",
+			stale:      []string{"querier", "ingester"},
+			blocked:    true,
+			conditions: []metav1.Condition{ready, inProgress},
+			wantUpdate: true,
+			want: metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonCARotationBlocked),
+				Message: "Signing CA rotation blocked by components not trusting the new CA: ingester, querier",
+				Status:  metav1.ConditionTrue,
+			},
+		},
+		{
+			name:       "rotation completed",
+			conditions: []metav1.Condition{ready, inProgress},
+			wantUpdate: true,
+			want: metav1.Condition{
+				Type:    string(lokiv1.ConditionWarning),
+				Reason:  string(lokiv1.ReasonCARotationInProgress),
+				Message: inProgress.Message,
+				Status:  metav1.ConditionFalse,
+			},
+		},
+		{
+			name:       "no rotation",
+			conditions: []metav1.Condition{ready},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				c, ok := ConditionsMap(obj.(*lokiv1.LokiStack))[tc.want.Type]
+				require.True(t, ok)
+				c.LastTransitionTime = metav1.Time{}
+				require.Equal(t, tc.want, c)
+				return nil
+			}
+
+			err := SetCARotationCondition(context.Background(), k, r, tc.stale, tc.blocked)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
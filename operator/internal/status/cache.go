@@ -0,0 +1,26 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetCacheCondition reports the reachability of the chunks and results caches, e.g. memcached or Redis.
+// An unreachable cache sets the condition Warning alongside the other conditions with the cache error
+// as detail. It is informational only: queries still succeed against the object storage, just slower,
+// thus the condition Ready is left untouched. A healthy cache clears a previously reported failure.
+func SetCacheCondition(ctx context.Context, k k8s.Client, req ctrl.Request, healthy bool, detail string) error {
+	if healthy {
+		return clearWarning(ctx, k, req, lokiv1.ReasonCacheUnreachable, "")
+	}
+
+	return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+		Reason:  lokiv1.ReasonCacheUnreachable,
+		Message: fmt.Sprintf("Cache unreachable, queries may be slower: %s", detail),
+	})
+}
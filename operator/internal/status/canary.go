@@ -0,0 +1,26 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetStorageCanaryCondition reports the latest result of the object storage write-then-read canary.
+// A failed canary sets the condition Warning alongside the other conditions with the canary error
+// as detail, because a reachable bucket may still reject writes, e.g. for read-only credentials.
+// A passing canary clears a previously reported failure.
+func SetStorageCanaryCondition(ctx context.Context, k k8s.Client, req ctrl.Request, ok bool, detail string) error {
+	if ok {
+		return clearWarning(ctx, k, req, lokiv1.ReasonObjectStorageCanaryFailed, "")
+	}
+
+	return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+		Reason:  lokiv1.ReasonObjectStorageCanaryFailed,
+		Message: fmt.Sprintf("Object storage write-then-read canary failed: %s", detail),
+	})
+}
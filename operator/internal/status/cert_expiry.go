@@ -0,0 +1,44 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetCertExpiryCondition reports the expiry of the soonest-expiring LokiStack certificate stored
+// in the given secret. If the certificate is expired the condition Degraded is set. If it expires
+// within the configured warning window the condition Warning is set alongside the other conditions.
+// Otherwise previously reported expiry conditions are cleared.
+func SetCertExpiryCondition(ctx context.Context, k k8s.Client, req ctrl.Request, earliestExpiry time.Time, secret string) error {
+	current := now()
+	expiry := earliestExpiry.UTC().Format(time.RFC3339)
+
+	switch {
+	case earliestExpiry.IsZero():
+		// no certificate known yet
+	case !current.Before(earliestExpiry):
+		msg := fmt.Sprintf("Certificate in secret %s expired at %s", secret, expiry)
+		return SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonCertificateExpired)
+	case earliestExpiry.Sub(current) <= options.CertExpiryWarningWindow:
+		if err := clearCondition(ctx, k, req, lokiv1.ConditionDegraded, lokiv1.ReasonCertificateExpired); err != nil {
+			return err
+		}
+
+		return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+			Reason:  lokiv1.ReasonCertificateExpiring,
+			Message: fmt.Sprintf("Certificate in secret %s expires at %s", secret, expiry),
+		})
+	}
+
+	if err := clearCondition(ctx, k, req, lokiv1.ConditionDegraded, lokiv1.ReasonCertificateExpired); err != nil {
+		return err
+	}
+
+	return clearWarning(ctx, k, req, lokiv1.ReasonCertificateExpiring, "")
+}
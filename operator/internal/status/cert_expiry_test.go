@@ -0,0 +1,148 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetCertExpiryCondition(t *testing.T) {
+	current := time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)
+
+	opts := DefaultOptions()
+	opts.Clock = clocktesting.NewFakePassiveClock(current)
+	opts.CertExpiryWarningWindow = 24 * time.Hour
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	expired := metav1.Condition{
+		Type:    string(lokiv1.ConditionDegraded),
+		Reason:  string(lokiv1.ReasonCertificateExpired),
+		Message: "Certificate in secret my-stack-ca expired at 2022-10-11T11:00:00Z",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name       string
+		expiry     time.Time
+		conditions []metav1.Condition
+		want       *metav1.Condition
+		wantReady  metav1.ConditionStatus
+	}{
+		{
+			name:       "outside warning window",
+			expiry:     current.Add(24*time.Hour + time.Second),
+			conditions: []metav1.Condition{ready},
+		},
+		{
+			name:       "at warning window boundary",
+			expiry:     current.Add(24 * time.Hour),
+			conditions: []metav1.Condition{ready},
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionWarning),
+				Reason:  string(lokiv1.ReasonCertificateExpiring),
+				Message: "Certificate in secret my-stack-ca expires at 2022-10-12T12:00:00Z",
+				Status:  metav1.ConditionTrue,
+			},
+			wantReady: metav1.ConditionTrue,
+		},
+		{
+			name:       "just before expiry",
+			expiry:     current.Add(time.Second),
+			conditions: []metav1.Condition{ready},
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionWarning),
+				Reason:  string(lokiv1.ReasonCertificateExpiring),
+				Message: "Certificate in secret my-stack-ca expires at 2022-10-11T12:00:01Z",
+				Status:  metav1.ConditionTrue,
+			},
+			wantReady: metav1.ConditionTrue,
+		},
+		{
+			name:       "at expiry",
+			expiry:     current,
+			conditions: []metav1.Condition{ready},
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonCertificateExpired),
+				Message: "Certificate in secret my-stack-ca expired at 2022-10-11T12:00:00Z",
+				Status:  metav1.ConditionTrue,
+			},
+			wantReady: metav1.ConditionFalse,
+		},
+		{
+			name:       "renewed after expiry clears degraded",
+			expiry:     current.Add(30 * 24 * time.Hour),
+			conditions: []metav1.Condition{expired},
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonCertificateExpired),
+				Message: expired.Message,
+				Status:  metav1.ConditionFalse,
+			},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+
+				got := conditions[tc.want.Type]
+				require.Equal(t, tc.want.Reason, got.Reason)
+				require.Equal(t, tc.want.Message, got.Message)
+				require.Equal(t, tc.want.Status, got.Status)
+
+				if tc.wantReady != "" {
+					require.Equal(t, tc.wantReady, conditions[string(lokiv1.ConditionReady)].Status)
+				}
+				return nil
+			}
+
+			err := SetCertExpiryCondition(context.Background(), k, r, tc.expiry, "my-stack-ca")
+			require.NoError(t, err)
+
+			if tc.want == nil {
+				require.Zero(t, sw.UpdateCallCount())
+			} else {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			}
+		})
+	}
+}
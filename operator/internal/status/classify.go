@@ -0,0 +1,114 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ClassifyAndApply inspects a reconcile error and sets the matching condition:
+// - A DegradedError sets the condition Degraded with its reason, message and involved object.
+// - A conflict is transient and retried on requeue, thus leaves all conditions untouched.
+// - A not found error sets the condition Degraded with reason MissingResource.
+// - A forbidden error sets the condition Degraded as classified by ClassifyForbidden.
+// - Any other error sets the condition Failed with reason ReconcileFailed.
+// A nil error is a no-op. The returned error reports only failed status writes.
+func ClassifyAndApply(ctx context.Context, k k8s.Client, req ctrl.Request, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var degraded *DegradedError
+	if forbidden, ok := ClassifyForbidden(err); ok {
+		degraded = forbidden
+	}
+	if degraded != nil || errors.As(err, &degraded) {
+		if err := SetDegradedCondition(ctx, k, req, degraded.ConditionMessage(), degraded.Reason); err != nil {
+			return err
+		}
+		return SetDegradedInvolvedObject(ctx, k, req, degraded.InvolvedObject)
+	}
+
+	switch {
+	case apierrors.IsConflict(err):
+		return nil
+	case apierrors.IsNotFound(err):
+		msg := fmt.Sprintf("Missing resource: %s", apiErrorMessage(err))
+		return SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonMissingResource)
+	default:
+		return updateCondition(ctx, k, req, metav1.Condition{
+			Type:    string(lokiv1.ConditionFailed),
+			Message: fmt.Sprintf("Reconcile failed: %s", err),
+			Reason:  string(lokiv1.ReasonReconcileFailed),
+		}, MutualExclusionPolicy)
+	}
+}
+
+// apiErrorMessage returns the message of the API status wrapped by err without
+// the context added by wrapping errors.
+func apiErrorMessage(err error) string {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		return status.Status().Message
+	}
+	return err.Error()
+}
+
+var (
+	// forbiddenVerb matches the verb in the message of forbidden errors returned by the
+	// apiserver authorizer, e.g. `User "x" cannot create resource "deployments"`.
+	forbiddenVerb = regexp.MustCompile(`cannot (\S+) resource`)
+	// forbiddenNamespace matches the namespace in the message of forbidden errors returned
+	// by the apiserver authorizer, e.g. `in the namespace "openshift-logging"`.
+	forbiddenNamespace = regexp.MustCompile(`in the namespace "([^"]+)"`)
+)
+
+// ClassifyForbidden returns a degraded error for a forbidden API error, i.e. the operator lacks
+// the RBAC permission to manage a resource required by the LokiStack. The message names the
+// denied verb, the resource and its namespace as far as reported by the apiserver, e.g.
+// "Missing RBAC permission to create deployments.apps in namespace some-ns". The error requests
+// a requeue, since an administrator may grant the permission at any time. It returns false for
+// any other error.
+func ClassifyForbidden(err error) (*DegradedError, bool) {
+	if !apierrors.IsForbidden(err) {
+		return nil, false
+	}
+
+	var (
+		msg      = apiErrorMessage(err)
+		verb     = "access"
+		resource = "resource"
+	)
+
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		if d := apiStatus.Status().Details; d != nil && d.Kind != "" {
+			resource = schema.GroupResource{Group: d.Group, Resource: d.Kind}.String()
+		}
+	}
+
+	if m := forbiddenVerb.FindStringSubmatch(msg); m != nil {
+		verb = m[1]
+	}
+
+	message := fmt.Sprintf("Missing RBAC permission to %s %s", verb, resource)
+	if m := forbiddenNamespace.FindStringSubmatch(msg); m != nil {
+		message = fmt.Sprintf("%s in namespace %s", message, m[1])
+	}
+
+	return &DegradedError{
+		Message:     message,
+		Reason:      lokiv1.ReasonForbiddenAccess,
+		Requeue:     true,
+		Remediation: "Grant the operator service account the missing permission",
+	}, true
+}
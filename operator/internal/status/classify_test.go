@@ -0,0 +1,176 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClassifyAndApply(t *testing.T) {
+	secrets := schema.GroupResource{Resource: "secrets"}
+
+	table := []struct {
+		name string
+		err  error
+		want *metav1.Condition
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name: "degraded error",
+			err: &DegradedError{
+				Message:     "Missing object storage secret",
+				Reason:      lokiv1.ReasonMissingObjectStorageSecret,
+				Remediation: "Create secret s3 in namespace some-ns",
+			},
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonMissingObjectStorageSecret),
+				Message: "Missing object storage secret (remediation: Create secret s3 in namespace some-ns)",
+			},
+		},
+		{
+			name: "wrapped degraded error",
+			err: kverrors.Wrap(&DegradedError{
+				Message: "Invalid tenants configuration",
+				Reason:  lokiv1.ReasonInvalidTenantsConfiguration,
+			}, "failed to build manifests"),
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonInvalidTenantsConfiguration),
+				Message: "Invalid tenants configuration",
+			},
+		},
+		{
+			name: "conflict",
+			err:  apierrors.NewConflict(secrets, "s3", nil),
+		},
+		{
+			name: "not found",
+			err:  kverrors.Wrap(apierrors.NewNotFound(secrets, "s3"), "failed to lookup secret"),
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonMissingResource),
+				Message: `Missing resource: secrets "s3" not found`,
+			},
+		},
+		{
+			name: "forbidden",
+			err:  apierrors.NewForbidden(secrets, "s3", errors.New("missing permissions")),
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  string(lokiv1.ReasonForbiddenAccess),
+				Message: "Missing RBAC permission to access secrets (remediation: Grant the operator service account the missing permission)",
+			},
+		},
+		{
+			name: "unknown error",
+			err:  kverrors.New("something went wrong"),
+			want: &metav1.Condition{
+				Type:    string(lokiv1.ConditionFailed),
+				Reason:  string(lokiv1.ReasonReconcileFailed),
+				Message: "Reconcile failed: something went wrong",
+			},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := obj.(*lokiv1.LokiStack).Status.Conditions
+				require.Len(t, conditions, 1)
+				require.Equal(t, tc.want.Type, conditions[0].Type)
+				require.Equal(t, tc.want.Reason, conditions[0].Reason)
+				require.Equal(t, tc.want.Message, conditions[0].Message)
+				require.Equal(t, metav1.ConditionTrue, conditions[0].Status)
+				return nil
+			}
+
+			err := ClassifyAndApply(context.Background(), k, r, tc.err)
+			require.NoError(t, err)
+
+			if tc.want == nil {
+				require.Zero(t, sw.UpdateCallCount())
+			} else {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestClassifyForbidden(t *testing.T) {
+	table := []struct {
+		name        string
+		err         error
+		wantMessage string
+	}{
+		{
+			name: "apiserver authorizer",
+			err: apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "my-stack-distributor",
+				errors.New(`User "system:serviceaccount:loki-operator:controller" cannot create resource "deployments" in API group "apps" in the namespace "some-ns"`)),
+			wantMessage: "Missing RBAC permission to create deployments.apps in namespace some-ns",
+		},
+		{
+			name: "cluster-scoped resource",
+			err: apierrors.NewForbidden(schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"}, "lokistack-gateway",
+				errors.New(`User "system:serviceaccount:loki-operator:controller" cannot update resource "clusterroles" in API group "rbac.authorization.k8s.io" at the cluster scope`)),
+			wantMessage: "Missing RBAC permission to update clusterroles.rbac.authorization.k8s.io",
+		},
+		{
+			name:        "wrapped without verb",
+			err:         kverrors.Wrap(apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "s3", errors.New("missing permissions")), "failed to lookup secret"),
+			wantMessage: "Missing RBAC permission to access secrets",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			degraded, ok := ClassifyForbidden(tc.err)
+			require.True(t, ok)
+			require.Equal(t, tc.wantMessage, degraded.Message)
+			require.Equal(t, lokiv1.ReasonForbiddenAccess, degraded.Reason)
+			require.True(t, degraded.Requeue)
+		})
+	}
+}
+
+func TestClassifyForbidden_WhenOtherError_ReturnFalse(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "s3"),
+		&DegradedError{Reason: lokiv1.ReasonForbiddenAccess},
+	} {
+		_, ok := ClassifyForbidden(err)
+		require.False(t, ok)
+	}
+}
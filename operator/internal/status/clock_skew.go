@@ -0,0 +1,36 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetClockSkewCondition reports the largest clock skew detected among the LokiStack components,
+// which otherwise shows up as out-of-order ingestion errors or missing query results. A skew of
+// at least the configured warning threshold sets the condition Warning, a skew of at least the
+// degraded threshold sets the condition Degraded. The sign of the skew is ignored. A skew below
+// both thresholds clears a previously reported clock skew warning.
+func SetClockSkewCondition(ctx context.Context, k k8s.Client, req ctrl.Request, skew time.Duration) error {
+	if skew < 0 {
+		skew = -skew
+	}
+	msg := fmt.Sprintf("Clock skew of %s detected among components", skew)
+
+	switch {
+	case skew >= options.ClockSkew.Degraded:
+		return SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonClockSkew)
+	case skew >= options.ClockSkew.Warning:
+		return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+			Reason:  lokiv1.ReasonClockSkew,
+			Message: msg,
+		})
+	default:
+		return clearWarning(ctx, k, req, lokiv1.ReasonClockSkew, "")
+	}
+}
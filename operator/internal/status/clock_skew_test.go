@@ -0,0 +1,142 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetClockSkewCondition(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	opts := DefaultOptions()
+	opts.ClockSkew = DurationThresholds{
+		Warning:  500 * time.Millisecond,
+		Degraded: 5 * time.Second,
+	}
+	Configure(opts)
+
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	skewWarning := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonClockSkew),
+		Message: "Clock skew of 2s detected among components",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name        string
+		skew        time.Duration
+		conditions  []metav1.Condition
+		wantUpdate  bool
+		wantType    lokiv1.LokiStackConditionType
+		wantStatus  metav1.ConditionStatus
+		wantMessage string
+		wantReady   metav1.ConditionStatus
+	}{
+		{
+			name:       "below warning threshold",
+			skew:       499 * time.Millisecond,
+			conditions: []metav1.Condition{ready},
+		},
+		{
+			name:        "below warning threshold clears warning",
+			skew:        100 * time.Millisecond,
+			conditions:  []metav1.Condition{ready, skewWarning},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionWarning,
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: skewWarning.Message,
+			wantReady:   metav1.ConditionTrue,
+		},
+		{
+			name:        "at warning threshold",
+			skew:        500 * time.Millisecond,
+			conditions:  []metav1.Condition{ready},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionWarning,
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "Clock skew of 500ms detected among components",
+			wantReady:   metav1.ConditionTrue,
+		},
+		{
+			name:        "negative skew below degraded threshold",
+			skew:        -4999 * time.Millisecond,
+			conditions:  []metav1.Condition{ready},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionWarning,
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "Clock skew of 4.999s detected among components",
+			wantReady:   metav1.ConditionTrue,
+		},
+		{
+			name:        "at degraded threshold",
+			skew:        5 * time.Second,
+			conditions:  []metav1.Condition{ready, skewWarning},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionDegraded,
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "Clock skew of 5s detected among components",
+			wantReady:   metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := SetClockSkewCondition(context.Background(), k, r, tc.skew)
+			require.NoError(t, err)
+
+			if !tc.wantUpdate {
+				require.Zero(t, sw.UpdateCallCount())
+				return
+			}
+
+			require.Equal(t, 1, sw.UpdateCallCount())
+			conditions := ConditionsMap(&s)
+			c := conditions[string(tc.wantType)]
+			require.Equal(t, tc.wantStatus, c.Status)
+			require.Equal(t, string(lokiv1.ReasonClockSkew), c.Reason)
+			require.Equal(t, tc.wantMessage, c.Message)
+			require.Equal(t, tc.wantReady, conditions[string(lokiv1.ConditionReady)].Status)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+type coalesceKey struct {
+	reason lokiv1.LokiStackConditionReason
+	code   string
+}
+
+// CoalesceDegradedErrors merges degraded errors sharing the same reason and code, e.g. the
+// same object storage outage reported by several components, into a single error. The
+// message, remediation and involved object of the first error in a group are kept and the
+// names of the affected components are appended to the message in sorted order. A merged
+// error requests a requeue if any error of its group does. Groups are returned in the order
+// of their first error.
+func CoalesceDegradedErrors(errs []*DegradedError) []*DegradedError {
+	var (
+		order      []coalesceKey
+		groups     = map[coalesceKey]*DegradedError{}
+		components = map[coalesceKey]map[string]struct{}{}
+	)
+
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+
+		key := coalesceKey{reason: e.Reason, code: e.Code}
+		merged, ok := groups[key]
+		if !ok {
+			merged = &DegradedError{
+				Message:        e.Message,
+				Reason:         e.Reason,
+				Remediation:    e.Remediation,
+				InvolvedObject: e.InvolvedObject,
+				Code:           e.Code,
+			}
+			groups[key] = merged
+			components[key] = map[string]struct{}{}
+			order = append(order, key)
+		}
+
+		merged.Requeue = merged.Requeue || e.Requeue
+		if e.Component != "" {
+			components[key][e.Component] = struct{}{}
+		}
+	}
+
+	res := make([]*DegradedError, 0, len(order))
+	for _, key := range order {
+		merged := groups[key]
+
+		names := make([]string, 0, len(components[key]))
+		for name := range components[key] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		switch {
+		case len(names) == 1:
+			merged.Component = names[0]
+			merged.Message = fmt.Sprintf("%s (affected component: %s)", merged.Message, names[0])
+		case len(names) > 1:
+			merged.Message = fmt.Sprintf("%s (affected components: %s)", merged.Message, strings.Join(names, ", "))
+		}
+
+		res = append(res, merged)
+	}
+
+	return res
+}
@@ -0,0 +1,82 @@
+package status_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalesceDegradedErrors_DuplicateCauses(t *testing.T) {
+	errs := []*status.DegradedError{
+		{
+			Message:   "Object storage unreachable",
+			Reason:    lokiv1.ReasonInvalidObjectStorageSecret,
+			Code:      "503",
+			Component: "ingester",
+		},
+		{
+			Message:   "Missing gateway tenant secret",
+			Reason:    lokiv1.ReasonMissingGatewayTenantSecret,
+			Component: "gateway",
+		},
+		{
+			Message:   "Object storage unreachable",
+			Reason:    lokiv1.ReasonInvalidObjectStorageSecret,
+			Code:      "503",
+			Requeue:   true,
+			Component: "compactor",
+		},
+		{
+			Message:   "Object storage access denied",
+			Reason:    lokiv1.ReasonInvalidObjectStorageSecret,
+			Code:      "403",
+			Component: "querier",
+		},
+		{
+			Message:   "Object storage unreachable",
+			Reason:    lokiv1.ReasonInvalidObjectStorageSecret,
+			Code:      "503",
+			Component: "ingester",
+		},
+		nil,
+	}
+
+	want := []*status.DegradedError{
+		{
+			Message: "Object storage unreachable (affected components: compactor, ingester)",
+			Reason:  lokiv1.ReasonInvalidObjectStorageSecret,
+			Code:    "503",
+			Requeue: true,
+		},
+		{
+			Message:   "Missing gateway tenant secret (affected component: gateway)",
+			Reason:    lokiv1.ReasonMissingGatewayTenantSecret,
+			Component: "gateway",
+		},
+		{
+			Message:   "Object storage access denied (affected component: querier)",
+			Reason:    lokiv1.ReasonInvalidObjectStorageSecret,
+			Code:      "403",
+			Component: "querier",
+		},
+	}
+
+	require.Equal(t, want, status.CoalesceDegradedErrors(errs))
+}
+
+func TestCoalesceDegradedErrors_WithoutComponents(t *testing.T) {
+	errs := []*status.DegradedError{
+		{Message: "Missing object storage secret", Reason: lokiv1.ReasonMissingObjectStorageSecret},
+		{Message: "Missing object storage secret", Reason: lokiv1.ReasonMissingObjectStorageSecret},
+	}
+
+	want := []*status.DegradedError{
+		{Message: "Missing object storage secret", Reason: lokiv1.ReasonMissingObjectStorageSecret},
+	}
+
+	require.Equal(t, want, status.CoalesceDegradedErrors(errs))
+	require.Empty(t, status.CoalesceDegradedErrors(nil))
+}
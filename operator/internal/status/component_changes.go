@@ -0,0 +1,59 @@
+package status
+
+import (
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ComponentChange describes a change of the pod status map of a LokiStack component written by this package.
+type ComponentChange struct {
+	// Stack is the namespaced name of the LokiStack.
+	Stack types.NamespacedName
+	// Component is the name of the component, e.g. ingester.
+	Component string
+	// Previous is the pod status map before the change.
+	Previous lokiv1.PodStatusMap
+	// Current is the pod status map after the change.
+	Current lokiv1.PodStatusMap
+}
+
+// componentStatusFields lists the pod status maps of LokiStackComponentStatus in ascending
+// order of the component names.
+var componentStatusFields = []struct {
+	name  string
+	field func(*lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap
+}{
+	{manifests.LabelCompactorComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.Compactor }},
+	{manifests.LabelDistributorComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.Distributor }},
+	{manifests.LabelIndexGatewayComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.IndexGateway }},
+	{manifests.LabelIngesterComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.Ingester }},
+	{manifests.LabelGatewayComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.Gateway }},
+	{manifests.LabelQuerierComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.Querier }},
+	{manifests.LabelQueryFrontendComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.QueryFrontend }},
+	{manifests.LabelRulerComponent, func(cs *lokiv1.LokiStackComponentStatus) lokiv1.PodStatusMap { return cs.Ruler }},
+}
+
+// componentChanges returns a change for every component whose pod status map differs between
+// previous and current, in ascending order of the component names. Missing and empty pod
+// status maps are considered equal.
+func componentChanges(key types.NamespacedName, previous, current lokiv1.LokiStackComponentStatus) []ComponentChange {
+	var changes []ComponentChange
+	for _, f := range componentStatusFields {
+		before, after := f.field(&previous), f.field(&current)
+		if equality.Semantic.DeepEqual(before, after) {
+			continue
+		}
+
+		changes = append(changes, ComponentChange{
+			Stack:     key,
+			Component: f.name,
+			Previous:  before,
+			Current:   after,
+		})
+	}
+
+	return changes
+}
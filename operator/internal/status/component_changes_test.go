@@ -0,0 +1,130 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetComponentsStatus_SingleComponentChanged(t *testing.T) {
+	sub := Subscribe(10)
+	t.Cleanup(func() { Unsubscribe(sub) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: lokiv1.LokiStackSpec{
+			Size: lokiv1.SizeOneXSmall,
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	ingesterPhase := corev1.PodRunning
+	k, sw := setupFakes(&s)
+	k.ListStub = func(_ context.Context, l client.ObjectList, o ...client.ListOption) error {
+		component := o[0].(client.MatchingLabels)["app.kubernetes.io/component"]
+
+		phase := corev1.PodRunning
+		if component == manifests.LabelIngesterComponent {
+			phase = ingesterPhase
+		}
+
+		k.SetClientObjectList(l, &corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: component + "-1"},
+					Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: component + "-0"},
+					Status:     corev1.PodStatus{Phase: phase},
+				},
+			},
+		})
+		return nil
+	}
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	receive := func() []ComponentChange {
+		var changes []ComponentChange
+		for len(sub.Components()) > 0 {
+			changes = append(changes, <-sub.Components())
+		}
+		return changes
+	}
+
+	// All components are new
+	err := SetComponentsStatus(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+	require.Equal(t, lokiv1.PodStatusMap{corev1.PodRunning: {"querier-0", "querier-1"}}, s.Status.Components.Querier)
+
+	var names []string
+	for _, c := range receive() {
+		names = append(names, c.Component)
+	}
+	require.Equal(t, []string{
+		manifests.LabelCompactorComponent,
+		manifests.LabelDistributorComponent,
+		manifests.LabelIndexGatewayComponent,
+		manifests.LabelIngesterComponent,
+		manifests.LabelGatewayComponent,
+		manifests.LabelQuerierComponent,
+		manifests.LabelQueryFrontendComponent,
+		manifests.LabelRulerComponent,
+	}, names)
+
+	// Nothing changed
+	err = SetComponentsStatus(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+	require.Empty(t, receive())
+
+	// Only the ingester changed
+	ingesterPhase = corev1.PodFailed
+	err = SetComponentsStatus(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 2, sw.UpdateCallCount())
+
+	changes := receive()
+	require.Len(t, changes, 1)
+	require.Equal(t, r.NamespacedName, changes[0].Stack)
+	require.Equal(t, manifests.LabelIngesterComponent, changes[0].Component)
+	require.Equal(t, lokiv1.PodStatusMap{corev1.PodRunning: {"ingester-0", "ingester-1"}}, changes[0].Previous)
+	require.Equal(t, lokiv1.PodStatusMap{
+		corev1.PodFailed:  {"ingester-0"},
+		corev1.PodRunning: {"ingester-1"},
+	}, changes[0].Current)
+}
+
+func TestComponentChanges_EmptyEqualsMissing(t *testing.T) {
+	previous := lokiv1.LokiStackComponentStatus{
+		Ruler: lokiv1.PodStatusMap{},
+	}
+	current := lokiv1.LokiStackComponentStatus{
+		Compactor: lokiv1.PodStatusMap{},
+	}
+
+	require.Empty(t, componentChanges(types.NamespacedName{}, previous, current))
+}
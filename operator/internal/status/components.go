@@ -2,6 +2,7 @@ package status
 
 import (
 	"context"
+	"sort"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
@@ -14,8 +15,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// SetComponentsStatus updates the pod status map component
+// SetComponentsStatus updates the pod status map component. The status is written only if the
+// pod status map of at least one component changed, and a ComponentChange is published to the
+// subscriptions for every changed component.
 func SetComponentsStatus(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	if options.Disabled {
+		return nil
+	}
+
 	var s lokiv1.LokiStack
 	if err := k.Get(ctx, req.NamespacedName, &s); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -25,6 +32,7 @@ func SetComponentsStatus(ctx context.Context, k k8s.Client, req ctrl.Request) er
 	}
 
 	var err error
+	previous := s.Status.Components
 	s.Status.Components = lokiv1.LokiStackComponentStatus{}
 	s.Status.Components.Compactor, err = appendPodStatus(ctx, k, manifests.LabelCompactorComponent, s.Name, s.Namespace)
 	if err != nil {
@@ -48,7 +56,7 @@ func SetComponentsStatus(ctx context.Context, k k8s.Client, req ctrl.Request) er
 
 	s.Status.Components.IndexGateway, err = appendPodStatus(ctx, k, manifests.LabelIndexGatewayComponent, s.Name, s.Namespace)
 	if err != nil {
-		return kverrors.Wrap(err, "failed lookup LokiStack component pods status", "name", manifests.LabelIngesterComponent)
+		return kverrors.Wrap(err, "failed lookup LokiStack component pods status", "name", manifests.LabelIndexGatewayComponent)
 	}
 
 	s.Status.Components.Ingester, err = appendPodStatus(ctx, k, manifests.LabelIngesterComponent, s.Name, s.Namespace)
@@ -66,7 +74,18 @@ func SetComponentsStatus(ctx context.Context, k k8s.Client, req ctrl.Request) er
 		return kverrors.Wrap(err, "failed lookup LokiStack component pods status", "name", manifests.LabelRulerComponent)
 	}
 
-	return k.Status().Update(ctx, &s, &client.UpdateOptions{})
+	changes := componentChanges(req.NamespacedName, previous, s.Status.Components)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := k.Status().Update(ctx, &s, &client.UpdateOptions{}); err != nil {
+		return err
+	}
+	recordOwnChange(req.NamespacedName, s.ResourceVersion)
+
+	publishComponentChanges(changes)
+	return nil
 }
 
 func appendPodStatus(ctx context.Context, k k8s.Client, component, stack, ns string) (lokiv1.PodStatusMap, error) {
@@ -83,5 +102,8 @@ func appendPodStatus(ctx context.Context, k k8s.Client, component, stack, ns str
 		phase := pod.Status.Phase
 		psm[phase] = append(psm[phase], pod.Name)
 	}
+	for _, names := range psm {
+		sort.Strings(names)
+	}
 	return psm, nil
 }
@@ -314,3 +314,67 @@ func TestSetComponentsStatus_WhenRulerNotEnabled_DoNothing(t *testing.T) {
 	require.NotZero(t, k.StatusCallCount())
 	require.NotZero(t, sw.UpdateCallCount())
 }
+
+func TestSetComponentsStatus_IndexGateway(t *testing.T) {
+	sw := &k8sfakes.FakeStatusWriter{}
+	k := &k8sfakes.FakeClient{}
+
+	k.StatusStub = func() client.StatusWriter { return sw }
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Spec: lokiv1.LokiStackSpec{
+			Size: lokiv1.SizeOneXSmall,
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		if r.Name == name.Name && r.Namespace == name.Namespace {
+			k.SetClientObject(object, &s)
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+
+	k.ListStub = func(_ context.Context, l client.ObjectList, o ...client.ListOption) error {
+		s := o[0].(client.MatchingLabels)
+		if s["app.kubernetes.io/component"] != "index-gateway" {
+			return nil
+		}
+
+		pods := v1.PodList{
+			Items: []v1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "index-gateway-0",
+					},
+					Status: v1.PodStatus{
+						Phase: v1.PodPending,
+					},
+				},
+			},
+		}
+		k.SetClientObjectList(l, &pods)
+		return nil
+	}
+
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		stack := obj.(*lokiv1.LokiStack)
+		require.Equal(t, lokiv1.PodStatusMap{"Pending": []string{"index-gateway-0"}}, stack.Status.Components.IndexGateway)
+		return nil
+	}
+
+	err := status.SetComponentsStatus(context.TODO(), k, r)
+	require.NoError(t, err)
+	require.NotZero(t, sw.UpdateCallCount())
+}
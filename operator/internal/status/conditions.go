@@ -0,0 +1,40 @@
+package status
+
+import (
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionsMap returns the LokiStack status conditions keyed by their type for repeated lookups.
+// The map holds copies of the conditions, i.e. modifying a map value does not modify the
+// LokiStack status and vice versa.
+func ConditionsMap(stack *lokiv1.LokiStack) map[string]metav1.Condition {
+	m := make(map[string]metav1.Condition, len(stack.Status.Conditions))
+	for _, c := range stack.Status.Conditions {
+		m[c.Type] = c
+	}
+
+	return m
+}
+
+// MostRecentCondition returns the LokiStack status condition with the latest LastTransitionTime,
+// regardless of its status. Conditions transitioned at the same time are ordered by their type,
+// i.e. the lexicographically smallest type wins. It returns false if the LokiStack has no conditions.
+func MostRecentCondition(stack *lokiv1.LokiStack) (metav1.Condition, bool) {
+	var (
+		recent metav1.Condition
+		found  bool
+	)
+
+	for _, c := range stack.Status.Conditions {
+		switch {
+		case !found,
+			recent.LastTransitionTime.Before(&c.LastTransitionTime),
+			recent.LastTransitionTime.Equal(&c.LastTransitionTime) && c.Type < recent.Type:
+			recent, found = c, true
+		}
+	}
+
+	return recent, found
+}
@@ -0,0 +1,125 @@
+package status_test
+
+import (
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionsMap(t *testing.T) {
+	stack := &lokiv1.LokiStack{
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(lokiv1.ConditionReady),
+					Reason: string(lokiv1.ReasonReadyComponents),
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   string(lokiv1.ConditionDegraded),
+					Reason: string(lokiv1.ReasonMissingObjectStorageSecret),
+					Status: metav1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	m := status.ConditionsMap(stack)
+	require.Len(t, m, 2)
+	require.Equal(t, metav1.ConditionTrue, m[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, string(lokiv1.ReasonMissingObjectStorageSecret), m[string(lokiv1.ConditionDegraded)].Reason)
+
+	// Values are copies of the stack conditions
+	c := m[string(lokiv1.ConditionReady)]
+	c.Status = metav1.ConditionFalse
+	m[string(lokiv1.ConditionReady)] = c
+	require.Equal(t, metav1.ConditionTrue, stack.Status.Conditions[0].Status)
+}
+
+func TestConditionsMap_WhenNoConditions_ReturnEmpty(t *testing.T) {
+	m := status.ConditionsMap(&lokiv1.LokiStack{})
+	require.Empty(t, m)
+}
+
+func TestMostRecentCondition(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2022, 10, 11, 12, minute, 0, 0, time.UTC))
+	}
+
+	table := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantType   string
+	}{
+		{
+			name: "latest transition",
+			conditions: []metav1.Condition{
+				{Type: string(lokiv1.ConditionReady), Status: metav1.ConditionFalse, LastTransitionTime: at(5)},
+				{Type: string(lokiv1.ConditionDegraded), Status: metav1.ConditionTrue, LastTransitionTime: at(10)},
+				{Type: string(lokiv1.ConditionPending), Status: metav1.ConditionFalse, LastTransitionTime: at(1)},
+			},
+			wantType: string(lokiv1.ConditionDegraded),
+		},
+		{
+			name: "tie broken by type",
+			conditions: []metav1.Condition{
+				{Type: string(lokiv1.ConditionWarning), Status: metav1.ConditionTrue, LastTransitionTime: at(10)},
+				{Type: string(lokiv1.ConditionReady), Status: metav1.ConditionTrue, LastTransitionTime: at(10)},
+				{Type: string(lokiv1.ConditionDegraded), Status: metav1.ConditionFalse, LastTransitionTime: at(3)},
+			},
+			wantType: string(lokiv1.ConditionReady),
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			stack := &lokiv1.LokiStack{
+				Status: lokiv1.LokiStackStatus{
+					Conditions: tc.conditions,
+				},
+			}
+
+			c, ok := status.MostRecentCondition(stack)
+			require.True(t, ok)
+			require.Equal(t, tc.wantType, c.Type)
+		})
+	}
+}
+
+func TestMostRecentCondition_WhenNoConditions_ReturnFalse(t *testing.T) {
+	_, ok := status.MostRecentCondition(&lokiv1.LokiStack{})
+	require.False(t, ok)
+}
+
+func TestManagedConditionTypes(t *testing.T) {
+	types := status.ManagedConditionTypes()
+
+	for _, want := range []lokiv1.LokiStackConditionType{
+		lokiv1.ConditionReady,
+		lokiv1.ConditionPending,
+		lokiv1.ConditionFailed,
+		lokiv1.ConditionDegraded,
+		lokiv1.ConditionWarning,
+		lokiv1.ConditionRecovering,
+		lokiv1.ConditionReadOnly,
+		lokiv1.ConditionFlapping,
+		lokiv1.ConditionPartiallyAvailable,
+		lokiv1.ConditionUpgrading,
+		lokiv1.ConditionBlockedQueries,
+	} {
+		require.Contains(t, types, string(want))
+	}
+	require.NotContains(t, types, "external.example.com/Custom")
+	require.IsIncreasing(t, types)
+
+	// Callers may modify the returned slice
+	types[0] = "modified"
+	require.NotContains(t, status.ManagedConditionTypes(), "modified")
+}
@@ -0,0 +1,102 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ConflictDetection defines when a LokiStack is considered managed by a conflicting controller.
+//
+// Detection is based on the number of resourceVersion changes observed at reconcile start
+// within the given window, excluding the status and annotation writes of this operator. It is
+// prone to false positives on any other frequent writer of the LokiStack object, e.g.:
+// - GitOps tools re-applying the resource with a short sync period.
+// - Other controllers maintaining labels, annotations or finalizers.
+// - A previous operator instance still writing during a leader election hand-over.
+type ConflictDetection struct {
+	// MaxChanges is the number of foreign changes tolerated within Window.
+	// Zero disables the detection.
+	MaxChanges int
+	// Window is the sliding time window in which changes are counted.
+	Window time.Duration
+}
+
+type churnHistory struct {
+	lastVersion string
+	ownVersion  string
+	changes     []time.Time
+}
+
+// conflicts records the observed resourceVersion changes per LokiStack.
+var conflicts = newStackStore[churnHistory]()
+
+// observeChange records the resourceVersion seen for the LokiStack and returns the number of
+// foreign changes within the window.
+func observeChange(key types.NamespacedName, version string, now time.Time, window time.Duration) int {
+	var count int
+	conflicts.update(key, func(h *churnHistory) {
+		if h.lastVersion != "" && version != h.lastVersion && version != h.ownVersion {
+			h.changes = append(h.changes, now)
+		}
+		h.lastVersion = version
+
+		cutoff := now.Add(-window)
+		for len(h.changes) > 0 && !h.changes[0].After(cutoff) {
+			h.changes = h.changes[1:]
+		}
+
+		count = len(h.changes)
+	})
+
+	return count
+}
+
+// recordOwnChange marks the resourceVersion as written by this operator. It must be called
+// with the resourceVersion returned by every write of the LokiStack made by this package.
+func recordOwnChange(key types.NamespacedName, version string) {
+	conflicts.update(key, func(h *churnHistory) {
+		h.ownVersion = version
+		h.lastVersion = version
+	})
+}
+
+// DetectConflictingController checks if the LokiStack churns faster than the configured
+// ConflictDetection threshold allows, e.g. because two operators manage the same stack.
+// If so, it sets the condition Degraded and returns true. Callers are expected to skip
+// reconciling resources to avoid taking part in the conflict.
+func DetectConflictingController(ctx context.Context, k k8s.Client, req ctrl.Request) (bool, error) {
+	cd := options.ConflictDetection
+	if cd.MaxChanges == 0 {
+		return false, nil
+	}
+
+	var stack lokiv1.LokiStack
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			conflicts.delete(req.NamespacedName)
+			return false, nil
+		}
+		return false, kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+	}
+
+	changes := observeChange(req.NamespacedName, stack.ResourceVersion, now(), cd.Window)
+	if changes <= cd.MaxChanges {
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("Possible conflicting controller: LokiStack changed %d times within %s", changes, cd.Window)
+	if err := SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonConflictingController); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
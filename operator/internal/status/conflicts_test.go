@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestObserveChange(t *testing.T) {
+	key := types.NamespacedName{Name: "my-stack", Namespace: "some-ns"}
+	t.Cleanup(func() { conflicts.delete(key) })
+	now := time.Now()
+
+	require.Zero(t, observeChange(key, "1", now, time.Minute))
+	require.Equal(t, 1, observeChange(key, "2", now.Add(time.Second), time.Minute))
+
+	// Versions written by the operator itself are not counted
+	recordOwnChange(key, "3")
+	require.Equal(t, 1, observeChange(key, "3", now.Add(2*time.Second), time.Minute))
+
+	require.Equal(t, 2, observeChange(key, "4", now.Add(3*time.Second), time.Minute))
+
+	// Changes outside the window expire
+	require.Equal(t, 1, observeChange(key, "5", now.Add(2*time.Minute), time.Minute))
+}
+
+func TestDetectConflictingController_WhenDisabled_DoNothing(t *testing.T) {
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, _ := setupFakes(&lokiv1.LokiStack{})
+
+	conflicting, err := DetectConflictingController(context.Background(), k, r)
+	require.NoError(t, err)
+	require.False(t, conflicting)
+	require.Zero(t, k.GetCallCount())
+}
+
+func TestDetectConflictingController_WhenThresholdExceeded_SetDegraded(t *testing.T) {
+	Configure(Options{
+		ConflictDetection: ConflictDetection{
+			MaxChanges: 1,
+			Window:     time.Hour,
+		},
+	})
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	t.Cleanup(func() { conflicts.delete(r.NamespacedName) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		actual := obj.(*lokiv1.LokiStack)
+		require.Len(t, actual.Status.Conditions, 1)
+		require.Equal(t, string(lokiv1.ConditionDegraded), actual.Status.Conditions[0].Type)
+		require.Equal(t, string(lokiv1.ReasonConflictingController), actual.Status.Conditions[0].Reason)
+		return nil
+	}
+
+	for i, version := range []string{"1", "2", "3"} {
+		s.ResourceVersion = version
+
+		conflicting, err := DetectConflictingController(context.Background(), k, r)
+		require.NoError(t, err)
+		require.Equal(t, i == 2, conflicting)
+	}
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestDetectConflictingController_IgnoreOwnWrites(t *testing.T) {
+	Configure(Options{
+		ConflictDetection: ConflictDetection{
+			MaxChanges: 1,
+			Window:     time.Hour,
+		},
+	})
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-stack",
+			Namespace:       "some-ns",
+			ResourceVersion: "1",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	t.Cleanup(func() { conflicts.delete(r.NamespacedName) })
+
+	// Every write bumps the resourceVersion as the apiserver does
+	version := 1
+	write := func(obj client.Object) {
+		version++
+		obj.SetResourceVersion(strconv.Itoa(version))
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		write(obj)
+		return nil
+	}
+	k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		write(obj)
+		return nil
+	}
+
+	secret := SecretReference("some-ns", "my-secret")
+	for i := 0; i < 4; i++ {
+		conflicting, err := DetectConflictingController(context.Background(), k, r)
+		require.NoError(t, err)
+		require.False(t, conflicting, "round %d", i)
+
+		if i%2 == 0 {
+			require.NoError(t, SetDegradedCondition(context.Background(), k, r, "Missing object storage secret", lokiv1.ReasonMissingObjectStorageSecret))
+			require.NoError(t, SetDegradedInvolvedObject(context.Background(), k, r, secret))
+		} else {
+			require.NoError(t, SetReadyCondition(context.Background(), k, r))
+			require.NoError(t, SetDegradedInvolvedObject(context.Background(), k, r, nil))
+		}
+	}
+	require.Equal(t, 4, sw.UpdateCallCount())
+	require.Equal(t, 4, k.UpdateCallCount())
+}
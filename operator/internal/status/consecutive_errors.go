@@ -0,0 +1,50 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const messageRetryingDegradedError = "Retrying after reconcile error"
+
+// consecutiveErrors counts the consecutive reconciliations per LokiStack failing with a degraded error.
+var consecutiveErrors = newStackStore[int]()
+
+// RecordDegradedError counts a reconciliation of the LokiStack failing with the degraded error. Once
+// the count reaches Options.DegradedErrorThreshold the condition Degraded is set with the error reason,
+// message and involved object. Below the threshold the condition Pending is set with the same reason
+// and message instead. It reports whether the condition Degraded was set. The count is kept in memory
+// until ResetDegradedErrors is called, i.e. an operator restart starts over.
+func RecordDegradedError(ctx context.Context, k k8s.Client, req ctrl.Request, degraded *DegradedError) (bool, error) {
+	var count int
+	consecutiveErrors.update(req.NamespacedName, func(c *int) {
+		*c++
+		count = *c
+	})
+
+	if count < options.DegradedErrorThreshold {
+		return false, updateCondition(ctx, k, req, metav1.Condition{
+			Type:    string(lokiv1.ConditionPending),
+			Message: fmt.Sprintf("%s: %s", messageRetryingDegradedError, degraded.ConditionMessage()),
+			Reason:  string(degraded.Reason),
+		}, MutualExclusionPolicy)
+	}
+
+	if err := SetDegradedCondition(ctx, k, req, degraded.ConditionMessage(), degraded.Reason); err != nil {
+		return true, err
+	}
+
+	return true, SetDegradedInvolvedObject(ctx, k, req, degraded.InvolvedObject)
+}
+
+// ResetDegradedErrors resets the count of consecutive degraded errors of the LokiStack, e.g. after
+// a successful reconciliation.
+func ResetDegradedErrors(req ctrl.Request) {
+	consecutiveErrors.delete(req.NamespacedName)
+}
@@ -0,0 +1,113 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecordDegradedError_Threshold(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	opts := DefaultOptions()
+	opts.DegradedErrorThreshold = 3
+	Configure(opts)
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	t.Cleanup(func() { ResetDegradedErrors(r) })
+
+	degraded := &DegradedError{
+		Message: "Missing object storage secret",
+		Reason:  lokiv1.ReasonMissingObjectStorageSecret,
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	requireActive := func(conditionType lokiv1.LokiStackConditionType, msg string) {
+		t.Helper()
+
+		conditions := ConditionsMap(&s)
+		c := conditions[string(conditionType)]
+		require.Equal(t, metav1.ConditionTrue, c.Status)
+		require.Equal(t, string(lokiv1.ReasonMissingObjectStorageSecret), c.Reason)
+		require.Equal(t, msg, c.Message)
+	}
+
+	for i := 0; i < 2; i++ {
+		set, err := RecordDegradedError(context.Background(), k, r, degraded)
+		require.NoError(t, err)
+		require.False(t, set)
+		requireActive(lokiv1.ConditionPending, "Retrying after reconcile error: Missing object storage secret")
+		require.NotContains(t, ConditionsMap(&s), string(lokiv1.ConditionDegraded))
+	}
+
+	set, err := RecordDegradedError(context.Background(), k, r, degraded)
+	require.NoError(t, err)
+	require.True(t, set)
+	requireActive(lokiv1.ConditionDegraded, "Missing object storage secret")
+	require.Equal(t, metav1.ConditionFalse, ConditionsMap(&s)[string(lokiv1.ConditionPending)].Status)
+
+	// A successful reconciliation starts over below the threshold
+	ResetDegradedErrors(r)
+
+	set, err = RecordDegradedError(context.Background(), k, r, degraded)
+	require.NoError(t, err)
+	require.False(t, set)
+	requireActive(lokiv1.ConditionPending, "Retrying after reconcile error: Missing object storage secret")
+}
+
+func TestRecordDegradedError_DefaultDegradesOnFirstError(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	t.Cleanup(func() { ResetDegradedErrors(r) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+		require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionDegraded)].Status)
+		return nil
+	}
+
+	set, err := RecordDegradedError(context.Background(), k, r, &DegradedError{
+		Message: "Missing object storage secret",
+		Reason:  lokiv1.ReasonMissingObjectStorageSecret,
+	})
+	require.NoError(t, err)
+	require.True(t, set)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
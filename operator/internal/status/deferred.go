@@ -0,0 +1,140 @@
+package status
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type deferredWritesKey struct{}
+
+// deferredWrite holds the status mutations accumulated for a single LokiStack.
+type deferredWrite struct {
+	k       k8s.Client
+	req     ctrl.Request
+	mutates []func(*lokiv1.LokiStack) bool
+}
+
+// deferredWrites buffers the status mutations of all LokiStacks in insertion order.
+type deferredWrites struct {
+	mu     sync.Mutex
+	order  []types.NamespacedName
+	writes map[types.NamespacedName]*deferredWrite
+}
+
+// DeferStatusWrites returns a context in which all status condition writes of this package are
+// accumulated instead of written immediately. FlushStatus writes the accumulated conditions of
+// each LokiStack at once. The returned function is meant to be deferred by the caller. It logs an
+// error if any accumulated writes were never flushed and discards them.
+//
+// Lookups of the LokiStack within the context do not observe the accumulated writes, e.g. Refresh
+// evaluates the conditions written before the context was created.
+func DeferStatusWrites(ctx context.Context) (context.Context, func()) {
+	d := &deferredWrites{writes: map[types.NamespacedName]*deferredWrite{}}
+
+	finish := func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		if len(d.order) == 0 {
+			return
+		}
+
+		err := kverrors.New("deferred status writes never flushed", "count", len(d.order))
+		ctrl.LoggerFrom(ctx).Error(err, "discarding LokiStack status writes")
+		d.order = nil
+		d.writes = map[types.NamespacedName]*deferredWrite{}
+	}
+
+	return context.WithValue(ctx, deferredWritesKey{}, d), finish
+}
+
+// FlushStatus writes the status conditions accumulated in a context returned by DeferStatusWrites
+// with a single status write per LokiStack. It is a no-op for any other context. A failed write
+// does not stop the writes of the other LokiStacks: its mutations are buffered again for the next
+// FlushStatus and the errors of all failed writes are returned as an aggregate.
+func FlushStatus(ctx context.Context) error {
+	d := deferredWritesFrom(ctx)
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	order, writes := d.order, d.writes
+	d.order = nil
+	d.writes = map[types.NamespacedName]*deferredWrite{}
+	d.mu.Unlock()
+
+	// Write through, i.e. without accumulating again
+	ctx = context.WithValue(ctx, deferredWritesKey{}, (*deferredWrites)(nil))
+
+	var errs []error
+	for _, key := range order {
+		w := writes[key]
+		err := updateStatus(ctx, w.k, w.req, func(stack *lokiv1.LokiStack) bool {
+			var changed bool
+			for _, mutate := range w.mutates {
+				if mutate(stack) {
+					changed = true
+				}
+			}
+			return changed
+		})
+		if err != nil {
+			errs = append(errs, err)
+			d.rebuffer(w)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// rebuffer accumulates the mutations of a failed write again ahead of any mutations
+// accumulated for the same LokiStack since the flush started.
+func (d *deferredWrites) rebuffer(w *deferredWrite) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := w.req.NamespacedName
+	if pending, ok := d.writes[key]; ok {
+		pending.mutates = append(w.mutates, pending.mutates...)
+		return
+	}
+
+	d.writes[key] = w
+	d.order = append(d.order, key)
+}
+
+func deferredWritesFrom(ctx context.Context) *deferredWrites {
+	d, _ := ctx.Value(deferredWritesKey{}).(*deferredWrites)
+	return d
+}
+
+// deferWrite accumulates the status mutation if the context defers status writes and
+// returns true. Otherwise it returns false and the caller must write immediately.
+func deferWrite(ctx context.Context, k k8s.Client, req ctrl.Request, mutate func(*lokiv1.LokiStack) bool) bool {
+	d := deferredWritesFrom(ctx)
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	w, ok := d.writes[req.NamespacedName]
+	if !ok {
+		w = &deferredWrite{k: k, req: req}
+		d.writes[req.NamespacedName] = w
+		d.order = append(d.order, req.NamespacedName)
+	}
+	w.mutates = append(w.mutates, mutate)
+
+	return true
+}
@@ -0,0 +1,149 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFlushStatus_WritesAccumulatedConditionsOnce(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	ctx, finish := DeferStatusWrites(context.Background())
+	defer finish()
+
+	require.NoError(t, SetPendingCondition(ctx, k, r))
+	require.NoError(t, SetReadyCondition(ctx, k, r))
+	require.NoError(t, SetWALPressureCondition(ctx, k, r, "ingester", 85))
+	require.Zero(t, sw.UpdateCallCount())
+
+	require.NoError(t, FlushStatus(ctx))
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionPending)].Status)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionWarning)].Status)
+
+	// Nothing left to flush
+	require.NoError(t, FlushStatus(ctx))
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestFlushStatus_WhenWriteFails_FlushOthersAndRebuffer(t *testing.T) {
+	stacks := map[types.NamespacedName]*lokiv1.LokiStack{}
+	for _, name := range []string{"failing-stack", "my-stack"} {
+		key := types.NamespacedName{Name: name, Namespace: "some-ns"}
+		stacks[key] = &lokiv1.LokiStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+		}
+	}
+
+	failing := ctrl.Request{NamespacedName: types.NamespacedName{Name: "failing-stack", Namespace: "some-ns"}}
+	r := ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-stack", Namespace: "some-ns"}}
+
+	sw := &k8sfakes.FakeStatusWriter{}
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		k.SetClientObject(object, stacks[name])
+		return nil
+	}
+	k.StatusStub = func() client.StatusWriter { return sw }
+
+	failWrites := true
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		if failWrites && obj.GetName() == failing.Name {
+			return apierrors.NewBadRequest("invalid status")
+		}
+		obj.(*lokiv1.LokiStack).DeepCopyInto(stacks[client.ObjectKeyFromObject(obj)])
+		return nil
+	}
+
+	ctx, finish := DeferStatusWrites(context.Background())
+	defer finish()
+
+	require.NoError(t, SetReadyCondition(ctx, k, failing))
+	require.NoError(t, SetReadyCondition(ctx, k, r))
+
+	err := FlushStatus(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid status")
+	require.Equal(t, 2, sw.UpdateCallCount())
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(stacks[r.NamespacedName])[string(lokiv1.ConditionReady)].Status)
+	require.Empty(t, stacks[failing.NamespacedName].Status.Conditions)
+
+	// The failed write is flushed again
+	failWrites = false
+	require.NoError(t, FlushStatus(ctx))
+	require.Equal(t, 3, sw.UpdateCallCount())
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(stacks[failing.NamespacedName])[string(lokiv1.ConditionReady)].Status)
+}
+
+func TestFlushStatus_WhenNotDeferred_DoNothing(t *testing.T) {
+	require.NoError(t, FlushStatus(context.Background()))
+}
+
+func TestDeferStatusWrites_WhenNotFlushed_LogError(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	var lines []string
+	logger := funcr.New(func(_, args string) { lines = append(lines, args) }, funcr.Options{})
+
+	k, sw := setupFakes(&s)
+
+	ctx, finish := DeferStatusWrites(ctrl.LoggerInto(context.Background(), logger))
+	require.NoError(t, SetReadyCondition(ctx, k, r))
+
+	finish()
+	require.Zero(t, sw.UpdateCallCount())
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], `"msg"="discarding LokiStack status writes"`)
+
+	// The discarded writes are not flushed later on
+	require.NoError(t, FlushStatus(ctx))
+	require.Zero(t, sw.UpdateCallCount())
+}
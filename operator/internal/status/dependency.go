@@ -0,0 +1,57 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// CustomResourceDefinitionReference returns an InvolvedObject for the custom resource definition
+// with the given name, e.g. servicemonitors.monitoring.coreos.com.
+func CustomResourceDefinitionReference(name string) *InvolvedObject {
+	return &InvolvedObject{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition", Name: name}
+}
+
+// ValidatingWebhookReference returns an InvolvedObject for the validating webhook configuration.
+func ValidatingWebhookReference(name string) *InvolvedObject {
+	return &InvolvedObject{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration", Name: name}
+}
+
+// MutatingWebhookReference returns an InvolvedObject for the mutating webhook configuration.
+func MutatingWebhookReference(name string) *InvolvedObject {
+	return &InvolvedObject{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration", Name: name}
+}
+
+// MissingCustomResourceDefinition returns a reference to the custom resource definition missing
+// for a "no matches for kind" error, as returned by the client for kinds not installed in the
+// cluster yet. The definition is named by the group and kind, e.g. ServiceMonitor.monitoring.coreos.com.
+// It returns false for any other error.
+func MissingCustomResourceDefinition(err error) (*InvolvedObject, bool) {
+	var noKind *meta.NoKindMatchError
+	if !errors.As(err, &noKind) {
+		return nil, false
+	}
+
+	return CustomResourceDefinitionReference(noKind.GroupKind.String()), true
+}
+
+// SetPendingDependencyCondition updates or appends the condition Pending with reason
+// PendingDependency naming the missing dependency, e.g. a custom resource definition or
+// an admission webhook not installed yet on a fresh cluster. In addition it resets all
+// other Status conditions to false.
+func SetPendingDependencyCondition(ctx context.Context, k k8s.Client, req ctrl.Request, dep *InvolvedObject) error {
+	pending := metav1.Condition{
+		Type:    string(lokiv1.ConditionPending),
+		Message: fmt.Sprintf("Waiting on missing dependency: %s %s", dep.Kind, dep.Name),
+		Reason:  string(lokiv1.ReasonPendingDependency),
+	}
+
+	return updateCondition(ctx, k, req, pending, MutualExclusionPolicy)
+}
@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetPendingDependencyCondition(t *testing.T) {
+	missingCRD, ok := MissingCustomResourceDefinition(&meta.NoKindMatchError{
+		GroupKind:        schema.GroupKind{Group: "monitoring.coreos.com", Kind: "ServiceMonitor"},
+		SearchedVersions: []string{"v1"},
+	})
+	require.True(t, ok)
+
+	table := []struct {
+		name        string
+		dep         *InvolvedObject
+		wantMessage string
+	}{
+		{
+			name:        "missing custom resource definition",
+			dep:         missingCRD,
+			wantMessage: "Waiting on missing dependency: CustomResourceDefinition ServiceMonitor.monitoring.coreos.com",
+		},
+		{
+			name:        "missing validating webhook",
+			dep:         ValidatingWebhookReference("loki-operator-validating-webhook"),
+			wantMessage: "Waiting on missing dependency: ValidatingWebhookConfiguration loki-operator-validating-webhook",
+		},
+		{
+			name:        "missing mutating webhook",
+			dep:         MutatingWebhookReference("pod-identity-webhook"),
+			wantMessage: "Waiting on missing dependency: MutatingWebhookConfiguration pod-identity-webhook",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:    string(lokiv1.ConditionPending),
+							Reason:  string(lokiv1.ReasonPendingComponents),
+							Message: messagePending,
+							Status:  metav1.ConditionTrue,
+						},
+					},
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := SetPendingDependencyCondition(context.Background(), k, r, tc.dep)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+
+			c := ConditionsMap(&s)[string(lokiv1.ConditionPending)]
+			require.Equal(t, metav1.ConditionTrue, c.Status)
+			require.Equal(t, string(lokiv1.ReasonPendingDependency), c.Reason)
+			require.Equal(t, tc.wantMessage, c.Message)
+		})
+	}
+}
+
+func TestMissingCustomResourceDefinition_WhenOtherError_ReturnFalse(t *testing.T) {
+	_, ok := MissingCustomResourceDefinition(context.DeadlineExceeded)
+	require.False(t, ok)
+}
@@ -0,0 +1,41 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AnnotationFailedDiagnostics is the LokiStack annotation referencing the diagnostics collected
+// for the last Failed condition, e.g. the name of a config map or a URL. It is only meaningful
+// while the Failed condition is true and removed once the LokiStack is Ready or Recovering.
+const AnnotationFailedDiagnostics = "loki.grafana.com/failedDiagnostics"
+
+// SetFailedConditionWithDiagnostics updates or appends the condition Failed with the given message
+// and reason to the lokistack status conditions and resets all other Status conditions to false.
+// In addition it stores the reference to the collected diagnostics in the AnnotationFailedDiagnostics
+// annotation. An empty reference removes the annotation. Collecting the diagnostics is up to the caller.
+func SetFailedConditionWithDiagnostics(
+	ctx context.Context,
+	k k8s.Client,
+	req ctrl.Request,
+	msg string,
+	reason lokiv1.LokiStackConditionReason,
+	diagRef string,
+) error {
+	failed := metav1.Condition{
+		Type:    string(lokiv1.ConditionFailed),
+		Message: msg,
+		Reason:  string(reason),
+	}
+
+	if err := updateCondition(ctx, k, req, failed, MutualExclusionPolicy); err != nil {
+		return err
+	}
+
+	return updateAnnotation(ctx, k, req, AnnotationFailedDiagnostics, diagRef)
+}
@@ -0,0 +1,61 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetFailedConditionWithDiagnostics(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+	k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := SetFailedConditionWithDiagnostics(context.Background(), k, r, "Ingester crashed", lokiv1.ReasonFailedComponents, "configmap/my-stack-diagnostics-1")
+	require.NoError(t, err)
+
+	c := ConditionsMap(&s)[string(lokiv1.ConditionFailed)]
+	require.Equal(t, metav1.ConditionTrue, c.Status)
+	require.Equal(t, string(lokiv1.ReasonFailedComponents), c.Reason)
+	require.Equal(t, "Ingester crashed", c.Message)
+	require.Equal(t, "configmap/my-stack-diagnostics-1", s.Annotations[AnnotationFailedDiagnostics])
+
+	// A new reference replaces the previous one for the same failure
+	err = SetFailedConditionWithDiagnostics(context.Background(), k, r, "Ingester crashed", lokiv1.ReasonFailedComponents, "https://diagnostics.example.com/my-stack/2")
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+	require.Equal(t, "https://diagnostics.example.com/my-stack/2", s.Annotations[AnnotationFailedDiagnostics])
+
+	// An empty reference removes the annotation
+	err = SetFailedConditionWithDiagnostics(context.Background(), k, r, "Ingester crashed", lokiv1.ReasonFailedComponents, "")
+	require.NoError(t, err)
+	require.NotContains(t, s.Annotations, AnnotationFailedDiagnostics)
+}
@@ -0,0 +1,104 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/metrics"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// FlappingDetection defines when the conditions of a LokiStack are considered flapping.
+//
+// Every status write of this package changing the status of at least one managed condition
+// counts as a single transition, e.g. flipping from Ready to Pending is one transition.
+type FlappingDetection struct {
+	// MaxTransitions is the number of transitions tolerated within Window.
+	// Zero disables the detection.
+	MaxTransitions int
+	// Window is the sliding time window in which transitions are counted.
+	Window time.Duration
+}
+
+type transitionHistory struct {
+	transitions []time.Time
+}
+
+// transitions records the condition transitions per LokiStack.
+var transitions = newStackStore[transitionHistory]()
+
+// recordTransition records a transition for the LokiStack if the status of any managed
+// condition differs between previous and written.
+func recordTransition(key types.NamespacedName, previous, written []metav1.Condition, now time.Time) {
+	if options.FlappingDetection.MaxTransitions == 0 || !managedStatusChanged(previous, written) {
+		return
+	}
+
+	transitions.update(key, func(h *transitionHistory) {
+		h.transitions = append(h.transitions, now)
+	})
+}
+
+// countTransitions prunes the transitions older than the window and returns the remaining count.
+func countTransitions(key types.NamespacedName, now time.Time, window time.Duration) int {
+	var count int
+	transitions.update(key, func(h *transitionHistory) {
+		cutoff := now.Add(-window)
+		for len(h.transitions) > 0 && !h.transitions[0].After(cutoff) {
+			h.transitions = h.transitions[1:]
+		}
+
+		count = len(h.transitions)
+	})
+
+	return count
+}
+
+func managedStatusChanged(previous, written []metav1.Condition) bool {
+	before := make(map[string]metav1.ConditionStatus, len(previous))
+	for _, c := range previous {
+		before[c.Type] = c.Status
+	}
+
+	for _, c := range written {
+		if !isManagedCondition(c.Type) {
+			continue
+		}
+
+		if s, ok := before[c.Type]; !ok || s != c.Status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DetectFlapping sets the condition Flapping alongside the other conditions if the LokiStack
+// conditions transitioned more often than the configured FlappingDetection threshold allows.
+// Once the transitions within the window fall back below the threshold, the condition is cleared.
+// The message names the threshold only, thus further transitions do not rewrite the condition.
+// The actual count is exposed as a metric instead.
+func DetectFlapping(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	fd := options.FlappingDetection
+	if fd.MaxTransitions == 0 {
+		return nil
+	}
+
+	count := countTransitions(req.NamespacedName, now(), fd.Window)
+	metrics.SetConditionTransitions(req.NamespacedName, count)
+	if count <= fd.MaxTransitions {
+		return clearCondition(ctx, k, req, lokiv1.ConditionFlapping, lokiv1.ReasonConditionsFlapping)
+	}
+
+	return updateCondition(ctx, k, req, metav1.Condition{
+		Type:    string(lokiv1.ConditionFlapping),
+		Message: fmt.Sprintf("LokiStack conditions transitioned more than %d times within %s", fd.MaxTransitions, fd.Window),
+		Reason:  string(lokiv1.ReasonConditionsFlapping),
+	}, CoexistPolicy)
+}
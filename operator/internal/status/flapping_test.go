@@ -0,0 +1,104 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDetectFlapping(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+
+	opts := DefaultOptions()
+	opts.Clock = fakeClock
+	opts.FlappingDetection = FlappingDetection{
+		MaxTransitions: 2,
+		Window:         time.Minute,
+	}
+	Configure(opts)
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	t.Cleanup(func() {
+		Configure(DefaultOptions())
+		transitions.delete(r.NamespacedName)
+	})
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	flapping := func() metav1.ConditionStatus {
+		return ConditionsMap(&s)[string(lokiv1.ConditionFlapping)].Status
+	}
+
+	// Two transitions are tolerated
+	require.NoError(t, SetPendingCondition(context.Background(), k, r))
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+	require.NoError(t, DetectFlapping(context.Background(), k, r))
+	require.Empty(t, flapping())
+
+	// Updates without a transition are not counted
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+	require.NoError(t, DetectFlapping(context.Background(), k, r))
+	require.Empty(t, flapping())
+
+	fakeClock.Step(10 * time.Second)
+	require.NoError(t, SetPendingCondition(context.Background(), k, r))
+	require.NoError(t, DetectFlapping(context.Background(), k, r))
+	require.Equal(t, metav1.ConditionTrue, flapping())
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, string(lokiv1.ReasonConditionsFlapping), conditions[string(lokiv1.ConditionFlapping)].Reason)
+	require.Equal(t, "LokiStack conditions transitioned more than 2 times within 1m0s", conditions[string(lokiv1.ConditionFlapping)].Message)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionPending)].Status)
+
+	// Further transitions do not rewrite the condition Flapping
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+	writes := sw.UpdateCallCount()
+	require.NoError(t, DetectFlapping(context.Background(), k, r))
+	require.Equal(t, writes, sw.UpdateCallCount())
+
+	// Transitions outside of the window are no longer counted
+	fakeClock.Step(time.Minute)
+	require.NoError(t, DetectFlapping(context.Background(), k, r))
+	require.Equal(t, metav1.ConditionFalse, flapping())
+}
+
+func TestDetectFlapping_WhenDisabled_DoNothing(t *testing.T) {
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, _ := setupFakes(&lokiv1.LokiStack{})
+
+	require.NoError(t, DetectFlapping(context.Background(), k, r))
+	require.Zero(t, k.GetCallCount())
+}
@@ -0,0 +1,137 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConditionsObject constrains T to the pointer type of the status-bearing custom resource O,
+// e.g. *lokiv1beta1.AlertingRule, so that the condition helpers can allocate it.
+type ConditionsObject[O any] interface {
+	client.Object
+	*O
+}
+
+// ConditionsFunc returns the status conditions of obj for reading and writing.
+type ConditionsFunc[T client.Object] func(obj T) *[]metav1.Condition
+
+// SetConditionOn sets the condition to true in the status conditions of the custom resource O
+// with the given key, e.g. an AlertingRule or a RulerConfig. The other conditions are updated
+// according to the given policy, which resets the condition types managed for LokiStacks, e.g.
+// Ready or Degraded. The status is written only if the condition is not active yet
+// with the same reason and message. Missing resources are ignored.
+func SetConditionOn[O any, T ConditionsObject[O]](
+	ctx context.Context,
+	k k8s.Client,
+	key types.NamespacedName,
+	conditions ConditionsFunc[T],
+	condition metav1.Condition,
+	policy ConditionPolicy,
+) error {
+	condition.Status = metav1.ConditionTrue
+
+	_, err := updateStatusOn[O, T](ctx, k, key, func(obj T) bool {
+		return setCondition(conditions(obj), condition, policy, metav1.NewTime(now()), nil)
+	}, statusHooks[T]{})
+	return err
+}
+
+// setCondition applies the condition to the conditions according to the policy and reports
+// whether they changed, i.e. whether the condition was not active yet. The pinned conditions
+// are passed to ConditionPolicy.apply.
+func setCondition(conditions *[]metav1.Condition, condition metav1.Condition, policy ConditionPolicy, now metav1.Time, pinned map[string]struct{}) bool {
+	if hasActiveCondition(*conditions, condition) {
+		// resource already has desired condition
+		return false
+	}
+
+	*conditions = policy.apply(*conditions, condition, now, pinned)
+	return true
+}
+
+// statusHooks lets a resource kind observe the steps of updateStatusOn. All hooks are optional.
+type statusHooks[T client.Object] struct {
+	// noop is called if mutate reports no change on the current resource.
+	noop func()
+	// written is called with the resource after each successful status write.
+	written func(obj T)
+	// done is called with the result and the duration of the status write attempts.
+	done func(err error, d time.Duration)
+	// write replaces the status update with a custom write of the mutated resource, e.g. a
+	// patch computed against before, the resource as looked up.
+	write func(ctx context.Context, before, obj T) error
+}
+
+// updateStatusOn looks up the custom resource O with the given key and applies mutate on it.
+// The status is written only if mutate reports a change. Conflicting and throttled writes are
+// retried with a fresh copy of the resource. It returns the written resource or nil if nothing
+// was written.
+func updateStatusOn[O any, T ConditionsObject[O]](
+	ctx context.Context,
+	k k8s.Client,
+	key types.NamespacedName,
+	mutate func(T) bool,
+	hooks statusHooks[T],
+) (T, error) {
+	obj := T(new(O))
+	if err := k.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, kverrors.Wrap(err, "failed to lookup resource", "name", key)
+	}
+
+	if !mutate(obj.DeepCopyObject().(T)) {
+		if hooks.noop != nil {
+			hooks.noop()
+		}
+		return nil, nil
+	}
+
+	write := hooks.write
+	if write == nil {
+		write = func(ctx context.Context, _, obj T) error {
+			return k.Status().Update(ctx, obj)
+		}
+	}
+
+	var written bool
+	start := time.Now()
+	err := retryOnConflictOrThrottling(func() error {
+		if err := k.Get(ctx, key, obj); err != nil {
+			return err
+		}
+
+		before := obj.DeepCopyObject().(T)
+		if !mutate(obj) {
+			return nil
+		}
+
+		if err := write(ctx, before, obj); err != nil {
+			return err
+		}
+
+		written = true
+		if hooks.written != nil {
+			hooks.written(obj)
+		}
+		return nil
+	})
+
+	if hooks.done != nil {
+		hooks.done(err, time.Since(start))
+	}
+
+	if err != nil || !written {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,127 @@
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func alertingRuleConditions(r *lokiv1beta1.AlertingRule) *[]metav1.Condition {
+	return &r.Status.Conditions
+}
+
+func setupAlertingRuleFakes(rule *lokiv1beta1.AlertingRule) (*k8sfakes.FakeClient, *k8sfakes.FakeStatusWriter) {
+	sw := &k8sfakes.FakeStatusWriter{}
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1beta1.AlertingRule).DeepCopyInto(rule)
+		return nil
+	}
+
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		if name.Name == rule.Name && name.Namespace == rule.Namespace {
+			k.SetClientObject(object, rule)
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+	k.StatusStub = func() client.StatusWriter { return sw }
+
+	return k, sw
+}
+
+func TestSetConditionOn_AlertingRule(t *testing.T) {
+	table := []struct {
+		name      string
+		policy    status.ConditionPolicy
+		wantReady metav1.ConditionStatus
+	}{
+		{
+			name:      "mutual exclusion resets other conditions",
+			policy:    status.MutualExclusionPolicy,
+			wantReady: metav1.ConditionFalse,
+		},
+		{
+			name:      "coexist preserves other conditions",
+			policy:    status.CoexistPolicy,
+			wantReady: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rule := lokiv1beta1.AlertingRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-rule",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1beta1.AlertingRuleStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(lokiv1.ConditionReady),
+							Reason: string(lokiv1.ReasonReadyComponents),
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			}
+			key := types.NamespacedName{Name: "my-rule", Namespace: "some-ns"}
+
+			degraded := metav1.Condition{
+				Type:    string(lokiv1.ConditionDegraded),
+				Reason:  "InvalidRuleExpression",
+				Message: "some degraded",
+			}
+
+			k, sw := setupAlertingRuleFakes(&rule)
+
+			err := status.SetConditionOn[lokiv1beta1.AlertingRule](context.Background(), k, key, alertingRuleConditions, degraded, tc.policy)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+
+			require.Len(t, rule.Status.Conditions, 2)
+			require.Equal(t, tc.wantReady, rule.Status.Conditions[0].Status)
+			require.Equal(t, degraded.Type, rule.Status.Conditions[1].Type)
+			require.Equal(t, degraded.Reason, rule.Status.Conditions[1].Reason)
+			require.Equal(t, degraded.Message, rule.Status.Conditions[1].Message)
+			require.Equal(t, metav1.ConditionTrue, rule.Status.Conditions[1].Status)
+
+			// Setting an already active condition is a no-op
+			err = status.SetConditionOn[lokiv1beta1.AlertingRule](context.Background(), k, key, alertingRuleConditions, degraded, tc.policy)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+		})
+	}
+}
+
+func TestSetConditionOn_WhenNotFound_DoNothing(t *testing.T) {
+	rule := lokiv1beta1.AlertingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-rule",
+			Namespace: "some-ns",
+		},
+	}
+	key := types.NamespacedName{Name: "other-rule", Namespace: "some-ns"}
+
+	k, sw := setupAlertingRuleFakes(&rule)
+
+	err := status.SetConditionOn[lokiv1beta1.AlertingRule](context.Background(), k, key, alertingRuleConditions, metav1.Condition{
+		Type:   string(lokiv1.ConditionReady),
+		Reason: string(lokiv1.ReasonReadyComponents),
+	}, status.CoexistPolicy)
+	require.NoError(t, err)
+	require.Zero(t, sw.UpdateCallCount())
+}
@@ -0,0 +1,44 @@
+package status
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+// hashedCondition defines the condition fields included in the status hash.
+// Timestamps and the observed generation change without a meaningful status change.
+type hashedCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// StatusHash returns the hex encoded SHA-256 hash of the LokiStack status conditions. The
+// conditions are sorted by type before hashing, thus the hash is stable across reorders. It is
+// stamped into Status.ConditionsHash on every status write.
+func StatusHash(stack *lokiv1.LokiStack) string {
+	conditions := make([]hashedCondition, 0, len(stack.Status.Conditions))
+	for _, c := range stack.Status.Conditions {
+		conditions = append(conditions, hashedCondition{
+			Type:    c.Type,
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+
+	sort.Slice(conditions, func(i, j int) bool {
+		return conditions[i].Type < conditions[j].Type
+	})
+
+	// Marshaling a slice of plain string structs cannot fail.
+	data, _ := json.Marshal(conditions)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
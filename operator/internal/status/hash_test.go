@@ -0,0 +1,85 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestStatusHash_StableAcrossReordersAndTimestamps(t *testing.T) {
+	ready := metav1.Condition{
+		Type:               string(lokiv1.ConditionReady),
+		Reason:             string(lokiv1.ReasonReadyComponents),
+		Message:            messageReady,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)),
+	}
+	pending := metav1.Condition{
+		Type:               string(lokiv1.ConditionPending),
+		Reason:             string(lokiv1.ReasonPendingComponents),
+		Message:            messagePending,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Date(2022, 10, 11, 11, 0, 0, 0, time.UTC)),
+	}
+
+	stack := func(conditions ...metav1.Condition) *lokiv1.LokiStack {
+		return &lokiv1.LokiStack{
+			Status: lokiv1.LokiStackStatus{Conditions: conditions},
+		}
+	}
+
+	want := StatusHash(stack(ready, pending))
+
+	require.Equal(t, want, StatusHash(stack(pending, ready)))
+
+	retouched := ready
+	retouched.LastTransitionTime = metav1.NewTime(time.Date(2022, 10, 12, 0, 0, 0, 0, time.UTC))
+	retouched.ObservedGeneration = 2
+	require.Equal(t, want, StatusHash(stack(retouched, pending)))
+
+	changed := ready
+	changed.Status = metav1.ConditionFalse
+	require.NotEqual(t, want, StatusHash(stack(changed, pending)))
+
+	require.NotEqual(t, want, StatusHash(stack(ready)))
+}
+
+func TestUpdateCondition_StampsStatusHashOnWrite(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		stack := obj.(*lokiv1.LokiStack)
+		require.NotEmpty(t, stack.Status.ConditionsHash)
+		require.Equal(t, StatusHash(stack), stack.Status.ConditionsHash)
+		return nil
+	}
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	// The hash is part of the status write, the LokiStack metadata is never written
+	require.Zero(t, k.UpdateCallCount())
+}
@@ -0,0 +1,127 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AnnotationDegradedInvolvedObject is the LokiStack annotation referencing the object causing
+// the last Degraded condition. It is only meaningful while the Degraded condition is true and
+// removed once the LokiStack is Ready or Recovering.
+const AnnotationDegradedInvolvedObject = "loki.grafana.com/degradedInvolvedObject"
+
+// InvolvedObject references the Kubernetes object causing a condition.
+type InvolvedObject struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// SecretReference returns an InvolvedObject for the secret.
+func SecretReference(namespace, name string) *InvolvedObject {
+	return &InvolvedObject{Kind: "Secret", Name: name, Namespace: namespace}
+}
+
+// ConfigMapReference returns an InvolvedObject for the config map.
+func ConfigMapReference(namespace, name string) *InvolvedObject {
+	return &InvolvedObject{Kind: "ConfigMap", Name: name, Namespace: namespace}
+}
+
+// SetDegradedInvolvedObject stores the reference in the AnnotationDegradedInvolvedObject
+// annotation of the LokiStack. A nil reference removes the annotation.
+func SetDegradedInvolvedObject(ctx context.Context, k k8s.Client, req ctrl.Request, obj *InvolvedObject) error {
+	var value string
+	if obj != nil {
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return kverrors.Wrap(err, "failed to marshal involved object", "name", obj.Name)
+		}
+		value = string(b)
+	}
+
+	return updateAnnotation(ctx, k, req, AnnotationDegradedInvolvedObject, value)
+}
+
+// recoveredAnnotations returns the annotations of the LokiStack describing a Degraded or Failed
+// condition which is not active anymore with an empty value, i.e. to be removed by updateAnnotations.
+func recoveredAnnotations(stack *lokiv1.LokiStack) map[string]string {
+	conditions := ConditionsMap(stack)
+
+	stale := map[string]string{}
+	for key, conditionType := range map[string]lokiv1.LokiStackConditionType{
+		AnnotationDegradedInvolvedObject: lokiv1.ConditionDegraded,
+		AnnotationFailedDiagnostics:      lokiv1.ConditionFailed,
+	} {
+		if _, ok := stack.Annotations[key]; ok && conditions[string(conditionType)].Status != metav1.ConditionTrue {
+			stale[key] = ""
+		}
+	}
+
+	return stale
+}
+
+// updateAnnotation sets the annotation of the LokiStack to value or removes it if value is empty.
+// The LokiStack is written only if the annotation changes.
+func updateAnnotation(ctx context.Context, k k8s.Client, req ctrl.Request, key, value string) error {
+	return updateAnnotations(ctx, k, req, map[string]string{key: value})
+}
+
+// updateAnnotations sets all given annotations of the LokiStack in a single write. Annotations
+// with an empty value are removed. The LokiStack is written only if any annotation changes.
+func updateAnnotations(ctx context.Context, k k8s.Client, req ctrl.Request, annotations map[string]string) error {
+	if options.Disabled {
+		return nil
+	}
+
+	return retryOnConflictOrThrottling(func() error {
+		var stack lokiv1.LokiStack
+		if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+		}
+
+		if !owns(&stack) {
+			return notOwnedError(req)
+		}
+
+		var changed bool
+		for key, value := range annotations {
+			current, ok := stack.Annotations[key]
+			switch {
+			case value == "" && !ok:
+			case value == "":
+				delete(stack.Annotations, key)
+				changed = true
+			case current == value:
+			default:
+				if stack.Annotations == nil {
+					stack.Annotations = map[string]string{}
+				}
+				stack.Annotations[key] = value
+				changed = true
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+
+		if err := k.Update(ctx, &stack); err != nil {
+			return err
+		}
+
+		recordOwnChange(req.NamespacedName, stack.ResourceVersion)
+		return nil
+	})
+}
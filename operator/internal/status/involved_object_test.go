@@ -0,0 +1,205 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetDegradedInvolvedObject(t *testing.T) {
+	annotation := `{"kind":"Secret","name":"my-secret","namespace":"some-ns"}`
+
+	table := []struct {
+		name        string
+		annotations map[string]string
+		obj         *InvolvedObject
+		want        map[string]string
+	}{
+		{
+			name: "add reference",
+			obj:  SecretReference("some-ns", "my-secret"),
+			want: map[string]string{
+				AnnotationDegradedInvolvedObject: annotation,
+			},
+		},
+		{
+			name: "replace reference",
+			annotations: map[string]string{
+				AnnotationDegradedInvolvedObject: `{"kind":"ConfigMap","name":"my-ca","namespace":"some-ns"}`,
+			},
+			obj: SecretReference("some-ns", "my-secret"),
+			want: map[string]string{
+				AnnotationDegradedInvolvedObject: annotation,
+			},
+		},
+		{
+			name: "remove reference",
+			annotations: map[string]string{
+				AnnotationDegradedInvolvedObject: annotation,
+				"other":                          "value",
+			},
+			want: map[string]string{
+				"other": "value",
+			},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-stack",
+					Namespace:   "some-ns",
+					Annotations: tc.annotations,
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, _ := setupFakes(&s)
+			k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				require.Equal(t, tc.want, obj.GetAnnotations())
+				return nil
+			}
+
+			err := SetDegradedInvolvedObject(context.Background(), k, r, tc.obj)
+			require.NoError(t, err)
+			require.Equal(t, 1, k.UpdateCallCount())
+		})
+	}
+}
+
+func TestSetDegradedInvolvedObject_WhenUnchanged_DoNothing(t *testing.T) {
+	table := []struct {
+		name        string
+		annotations map[string]string
+		obj         *InvolvedObject
+	}{
+		{
+			name: "same reference",
+			annotations: map[string]string{
+				AnnotationDegradedInvolvedObject: `{"kind":"ConfigMap","name":"my-ca","namespace":"some-ns"}`,
+			},
+			obj: ConfigMapReference("some-ns", "my-ca"),
+		},
+		{
+			name: "no reference",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-stack",
+					Namespace:   "some-ns",
+					Annotations: tc.annotations,
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, _ := setupFakes(&s)
+
+			err := SetDegradedInvolvedObject(context.Background(), k, r, tc.obj)
+			require.NoError(t, err)
+			require.Zero(t, k.UpdateCallCount())
+		})
+	}
+}
+
+func TestSetReadyCondition_RemoveRecoveredAnnotations(t *testing.T) {
+	degraded := metav1.Condition{
+		Type:    string(lokiv1.ConditionDegraded),
+		Reason:  string(lokiv1.ReasonMissingObjectStorageSecret),
+		Message: "Missing object storage secret",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name        string
+		annotations map[string]string
+		want        map[string]string
+	}{
+		{
+			name: "remove degraded and failed annotations",
+			annotations: map[string]string{
+				AnnotationDegradedInvolvedObject: `{"kind":"Secret","name":"my-secret","namespace":"some-ns"}`,
+				AnnotationFailedDiagnostics:      "my-stack-diagnostics",
+				"other":                          "value",
+			},
+			want: map[string]string{
+				"other": "value",
+			},
+		},
+		{
+			name: "keep annotation of pinned condition",
+			annotations: map[string]string{
+				AnnotationPinnedConditions:       "Degraded/MissingObjectStorageSecret",
+				AnnotationDegradedInvolvedObject: `{"kind":"Secret","name":"my-secret","namespace":"some-ns"}`,
+			},
+		},
+		{
+			name: "no annotations",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-stack",
+					Namespace:   "some-ns",
+					Annotations: tc.annotations,
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: []metav1.Condition{degraded},
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				require.Equal(t, tc.want, obj.GetAnnotations())
+				return nil
+			}
+
+			err := SetReadyCondition(context.Background(), k, r)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+
+			if tc.want == nil {
+				require.Zero(t, k.UpdateCallCount())
+			} else {
+				require.Equal(t, 1, k.UpdateCallCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package status
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+// ConditionsJSONVersion is the version of the schema produced by ConditionsJSON. It changes only
+// on incompatible changes, i.e. fields are never renamed or removed within a version.
+const ConditionsJSONVersion = "v1"
+
+// conditionsDocument is the schema produced by ConditionsJSON.
+type conditionsDocument struct {
+	// Version is always ConditionsJSONVersion.
+	Version string `json:"version"`
+	// Namespace of the LokiStack.
+	Namespace string `json:"namespace"`
+	// Name of the LokiStack.
+	Name string `json:"name"`
+	// Phase is the type of the highest-priority active condition as returned by Phase or
+	// empty if none is active.
+	Phase string `json:"phase"`
+	// PhaseAgeSeconds is the number of seconds since the phase condition became active.
+	PhaseAgeSeconds int64 `json:"phaseAgeSeconds"`
+	// Conditions are all status conditions sorted by type.
+	Conditions []conditionDocument `json:"conditions"`
+}
+
+// conditionDocument is a single status condition in the schema produced by ConditionsJSON.
+type conditionDocument struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+	// AgeSeconds is the number of seconds since the last transition of the condition.
+	AgeSeconds int64 `json:"ageSeconds"`
+}
+
+// ConditionsJSON returns an indented JSON document of the LokiStack status conditions for
+// command line tools. Besides the conditions, the document holds the derived phase and its age.
+// Transition times are formatted as RFC 3339 in UTC and ages are relative to the time of the
+// configured clock. The schema is versioned by ConditionsJSONVersion.
+func ConditionsJSON(stack *lokiv1.LokiStack) ([]byte, error) {
+	current := now()
+
+	doc := conditionsDocument{
+		Version:    ConditionsJSONVersion,
+		Namespace:  stack.Namespace,
+		Name:       stack.Name,
+		Conditions: []conditionDocument{},
+	}
+
+	if c, ok := phaseCondition(stack); ok {
+		doc.Phase = c.Type
+		doc.PhaseAgeSeconds = ageSeconds(c.LastTransitionTime.Time, current)
+	}
+
+	for _, c := range stack.Status.Conditions {
+		doc.Conditions = append(doc.Conditions, conditionDocument{
+			Type:               c.Type,
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime.UTC().Format(time.RFC3339),
+			AgeSeconds:         ageSeconds(c.LastTransitionTime.Time, current),
+		})
+	}
+
+	sort.Slice(doc.Conditions, func(i, j int) bool {
+		return doc.Conditions[i].Type < doc.Conditions[j].Type
+	})
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, kverrors.Wrap(err, "failed to marshal lokistack conditions", "name", stack.Name)
+	}
+
+	return b, nil
+}
+
+func ageSeconds(since, now time.Time) int64 {
+	if since.IsZero() || since.After(now) {
+		return 0
+	}
+	return int64(now.Sub(since) / time.Second)
+}
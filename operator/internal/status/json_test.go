@@ -0,0 +1,74 @@
+package status
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestConditionsJSON_Golden(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Clock = clocktesting.NewFakePassiveClock(time.Date(2022, 10, 11, 13, 0, 0, 0, time.UTC))
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	stack := &lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionWarning),
+					Reason:             string(lokiv1.ReasonWALDiskPressure),
+					Message:            "Write ahead log disk of ingester is 85% full",
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Date(2022, 10, 11, 12, 30, 0, 0, time.UTC)),
+				},
+				{
+					Type:               string(lokiv1.ConditionReady),
+					Reason:             string(lokiv1.ReasonReadyComponents),
+					Message:            messageReady,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)),
+				},
+				{
+					Type:               string(lokiv1.ConditionPending),
+					Reason:             string(lokiv1.ReasonPendingComponents),
+					Message:            messagePending,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+	}
+
+	got, err := ConditionsJSON(stack)
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("testdata/conditions.golden.json")
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(got))
+	require.Equal(t, string(want), string(got)+"\n")
+}
+
+func TestConditionsJSON_WithoutConditions(t *testing.T) {
+	stack := &lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	got, err := ConditionsJSON(stack)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":"v1","namespace":"some-ns","name":"my-stack","phase":"","phaseAgeSeconds":0,"conditions":[]}`, string(got))
+}
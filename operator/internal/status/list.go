@@ -0,0 +1,60 @@
+package status
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listPageSize is the maximum number of LokiStacks fetched per list request.
+const listPageSize = 100
+
+// ListByCondition returns all LokiStacks across all namespaces with the given condition type
+// currently set to true. The LokiStacks are listed in pages to support large fleets.
+func ListByCondition(ctx context.Context, k k8s.Client, conditionType lokiv1.LokiStackConditionType) ([]lokiv1.LokiStack, error) {
+	var (
+		stacks []lokiv1.LokiStack
+		token  string
+	)
+
+	for {
+		var list lokiv1.LokiStackList
+		opts := []client.ListOption{
+			client.Limit(listPageSize),
+		}
+		if token != "" {
+			opts = append(opts, client.Continue(token))
+		}
+
+		if err := k.List(ctx, &list, opts...); err != nil {
+			return nil, kverrors.Wrap(err, "failed to list LokiStacks", "condition", conditionType)
+		}
+
+		for _, s := range list.Items {
+			if isConditionActive(s.Status.Conditions, conditionType) {
+				stacks = append(stacks, s)
+			}
+		}
+
+		token = list.Continue
+		if token == "" {
+			return stacks, nil
+		}
+	}
+}
+
+// isConditionActive returns true if the conditions contain the given condition type set to true.
+func isConditionActive(conditions []metav1.Condition, conditionType lokiv1.LokiStackConditionType) bool {
+	for _, c := range conditions {
+		if c.Type == string(conditionType) && c.Status == metav1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
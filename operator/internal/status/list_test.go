@@ -0,0 +1,81 @@
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newStackWithCondition(name string, conditionType lokiv1.LokiStackConditionType, conditionStatus metav1.ConditionStatus) lokiv1.LokiStack {
+	return lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(conditionType),
+					Status: conditionStatus,
+				},
+			},
+		},
+	}
+}
+
+func TestListByCondition_WhenListReturnsError_ReturnError(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	k.ListStub = func(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+		return apierrors.NewBadRequest("something went wrong")
+	}
+
+	_, err := status.ListByCondition(context.TODO(), k, lokiv1.ConditionDegraded)
+	require.Error(t, err)
+}
+
+func TestListByCondition_FollowsContinueToken(t *testing.T) {
+	pages := map[string]lokiv1.LokiStackList{
+		"": {
+			ListMeta: metav1.ListMeta{Continue: "page-2"},
+			Items: []lokiv1.LokiStack{
+				newStackWithCondition("degraded-1", lokiv1.ConditionDegraded, metav1.ConditionTrue),
+				newStackWithCondition("ready", lokiv1.ConditionReady, metav1.ConditionTrue),
+			},
+		},
+		"page-2": {
+			Items: []lokiv1.LokiStack{
+				newStackWithCondition("recovered", lokiv1.ConditionDegraded, metav1.ConditionFalse),
+				newStackWithCondition("degraded-2", lokiv1.ConditionDegraded, metav1.ConditionTrue),
+			},
+		},
+	}
+
+	k := &k8sfakes.FakeClient{}
+	k.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		lo := &client.ListOptions{}
+		lo.ApplyOptions(opts)
+
+		page := pages[lo.Continue]
+		k.SetClientObjectList(list, &page)
+		return nil
+	}
+
+	stacks, err := status.ListByCondition(context.TODO(), k, lokiv1.ConditionDegraded)
+	require.NoError(t, err)
+	require.Equal(t, 2, k.ListCallCount())
+
+	var names []string
+	for _, s := range stacks {
+		names = append(names, s.Name)
+	}
+	require.Equal(t, []string{"degraded-1", "degraded-2"}, names)
+}
@@ -3,21 +3,71 @@ package status
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
-	"github.com/ViaQ/logerr/v2/kverrors"
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	"github.com/grafana/loki/operator/internal/external/k8s"
-	"k8s.io/client-go/util/retry"
+	"github.com/grafana/loki/operator/internal/metrics"
+	"github.com/grafana/loki/operator/internal/version"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+// managedConditionTypes defines the set of condition types owned by the operator.
+// Conditions of any other type are considered owned by external controllers.
+// The conditions listed in auxiliaryConditionTypes are owned by the operator too, but describe
+// a mode, a history or a partial state and are thus never reset when another condition is set.
+var managedConditionTypes = map[string]struct{}{
+	string(lokiv1.ConditionReady):      {},
+	string(lokiv1.ConditionPending):    {},
+	string(lokiv1.ConditionFailed):     {},
+	string(lokiv1.ConditionDegraded):   {},
+	string(lokiv1.ConditionWarning):    {},
+	string(lokiv1.ConditionRecovering): {},
+}
+
+// auxiliaryConditionTypes defines the condition types owned by the operator besides
+// managedConditionTypes, which are only ever set and cleared on their own.
+var auxiliaryConditionTypes = []string{
+	string(lokiv1.ConditionReadOnly),
+	string(lokiv1.ConditionFlapping),
+	string(lokiv1.ConditionPartiallyAvailable),
+	string(lokiv1.ConditionUpgrading),
+	string(lokiv1.ConditionBlockedQueries),
+}
+
+func isManagedCondition(conditionType string) bool {
+	_, ok := managedConditionTypes[conditionType]
+	return ok
+}
+
+// ManagedConditionTypes returns the sorted condition types written by this package, i.e. the
+// types reset by the condition policies and the auxiliary types ReadOnly, Flapping,
+// PartiallyAvailable, Upgrading and BlockedQueries. Conditions of any other type are owned by
+// external controllers. New condition types must be added to managedConditionTypes or auxiliaryConditionTypes.
+func ManagedConditionTypes() []string {
+	types := make([]string, 0, len(managedConditionTypes)+len(auxiliaryConditionTypes))
+	for t := range managedConditionTypes {
+		types = append(types, t)
+	}
+	types = append(types, auxiliaryConditionTypes...)
+	sort.Strings(types)
+
+	return types
+}
+
 const (
-	messageReady   = "All components ready"
-	messageFailed  = "Some LokiStack components failed"
-	messagePending = "Some LokiStack components pending on dependencies"
+	messageReady      = "All components ready"
+	messageFailed     = "Some LokiStack components failed"
+	messagePending    = "Some LokiStack components pending on dependencies"
+	messageRecovering = "All components ready, recovering from a degraded or failed state"
+
+	// maxFailedRequeueAfter caps the backoff for requeueing failed LokiStacks.
+	maxFailedRequeueAfter = 5 * time.Minute
 )
 
 // DegradedError contains information about why the managed LokiStack has an invalid configuration.
@@ -25,34 +75,107 @@ type DegradedError struct {
 	Message string
 	Reason  lokiv1.LokiStackConditionReason
 	Requeue bool
+	// Remediation optionally describes the user action resolving the degraded state,
+	// e.g. "Create secret X in namespace Y".
+	Remediation string
+	// InvolvedObject optionally references the object causing the degraded state.
+	InvolvedObject *InvolvedObject
+	// Code optionally identifies the root cause within the reason, e.g. the error code
+	// returned by the object storage. Errors with the same reason and code are coalesced.
+	Code string
+	// Component optionally names the LokiStack component reporting the degraded state.
+	Component string
 }
 
 func (e *DegradedError) Error() string {
 	return fmt.Sprintf("cluster degraded: %s", e.Message)
 }
 
+// ConditionMessage returns the message for the condition Degraded. If a remediation
+// is set, it is appended to the message.
+func (e *DegradedError) ConditionMessage() string {
+	if e.Remediation == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s (remediation: %s)", e.Message, e.Remediation)
+}
+
 // SetReadyCondition updates or appends the condition Ready to the lokistack status conditions.
-// In addition it resets all other Status conditions to false.
+// In addition it resets all other Status conditions including PartiallyAvailable to false and
+// removes the annotations describing the last Degraded or Failed condition.
 func SetReadyCondition(ctx context.Context, k k8s.Client, req ctrl.Request) error {
 	ready := metav1.Condition{
 		Type:    string(lokiv1.ConditionReady),
-		Message: messageReady,
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionReady, LocalizableMessage{Key: MessageKeyReady}),
 		Reason:  string(lokiv1.ReasonReadyComponents),
 	}
 
-	return updateCondition(ctx, k, req, ready)
+	return setRecoveredCondition(ctx, k, req, ready)
+}
+
+// setRecoveredCondition sets the condition with the MutualExclusionPolicy like updateCondition,
+// resets the condition PartiallyAvailable, since all component pods are running, and removes the AnnotationDegradedInvolvedObject and AnnotationFailedDiagnostics annotations
+// afterwards, since they are not meaningful anymore once Degraded and Failed are reset. The
+// LokiStack is only written again if any of the annotations is stale.
+func setRecoveredCondition(ctx context.Context, k k8s.Client, req ctrl.Request, condition metav1.Condition) error {
+	condition.Status = metav1.ConditionTrue
+	recordAssertion(req.NamespacedName, condition.Type, now())
+
+	var stale map[string]string
+	err := updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		changed := setStackCondition(stack, condition, MutualExclusionPolicy)
+		changed = resetPartiallyAvailable(stack) || changed
+		stale = recoveredAnnotations(stack)
+		return changed
+	})
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+
+	return updateAnnotations(ctx, k, req, stale)
 }
 
 // SetFailedCondition updates or appends the condition Failed to the lokistack status conditions.
-// In addition it resets all other Status conditions to false.
-func SetFailedCondition(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+// In addition it resets all other Status conditions to false. The names of the affected pods, if
+// any, are appended to the message as described by withPods.
+func SetFailedCondition(ctx context.Context, k k8s.Client, req ctrl.Request, pods ...string) error {
 	failed := metav1.Condition{
 		Type:    string(lokiv1.ConditionFailed),
-		Message: messageFailed,
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionFailed, podsMessage(MessageKeyFailed, MessageKeyFailedPods, pods)),
 		Reason:  string(lokiv1.ReasonFailedComponents),
 	}
 
-	return updateCondition(ctx, k, req, failed)
+	return updateCondition(ctx, k, req, failed, MutualExclusionPolicy)
+}
+
+// SetFailedConditionWithRequeue updates or appends the condition Failed with the given message
+// and reason to the lokistack status conditions. In addition it resets all other Status conditions
+// to false. On success it returns a result requeueing the request after the given duration capped
+// at maxFailedRequeueAfter.
+func SetFailedConditionWithRequeue(
+	ctx context.Context,
+	k k8s.Client,
+	req ctrl.Request,
+	msg string,
+	reason lokiv1.LokiStackConditionReason,
+	after time.Duration,
+) (ctrl.Result, error) {
+	failed := metav1.Condition{
+		Type:    string(lokiv1.ConditionFailed),
+		Message: msg,
+		Reason:  string(reason),
+	}
+
+	if err := updateCondition(ctx, k, req, failed, MutualExclusionPolicy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if after > maxFailedRequeueAfter {
+		after = maxFailedRequeueAfter
+	}
+
+	return ctrl.Result{RequeueAfter: after}, nil
 }
 
 // SetPendingCondition updates or appends the condition Pending to the lokistack status conditions.
@@ -60,71 +183,248 @@ func SetFailedCondition(ctx context.Context, k k8s.Client, req ctrl.Request) err
 func SetPendingCondition(ctx context.Context, k k8s.Client, req ctrl.Request) error {
 	pending := metav1.Condition{
 		Type:    string(lokiv1.ConditionPending),
-		Message: messagePending,
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionPending, LocalizableMessage{Key: MessageKeyPending}),
 		Reason:  string(lokiv1.ReasonPendingComponents),
 	}
 
-	return updateCondition(ctx, k, req, pending)
+	return updateCondition(ctx, k, req, pending, MutualExclusionPolicy)
 }
 
-// SetDegradedCondition appends the condition Degraded to the lokistack status conditions.
-func SetDegradedCondition(ctx context.Context, k k8s.Client, req ctrl.Request, msg string, reason lokiv1.LokiStackConditionReason) error {
+// SetDegradedCondition appends the condition Degraded to the lokistack status conditions. The names
+// of the affected pods, if any, are appended to the message as described by withPods.
+func SetDegradedCondition(ctx context.Context, k k8s.Client, req ctrl.Request, msg string, reason lokiv1.LokiStackConditionReason, pods ...string) error {
 	degraded := metav1.Condition{
 		Type:    string(lokiv1.ConditionDegraded),
-		Message: msg,
+		Message: withPods(msg, pods),
 		Reason:  string(reason),
 	}
 
-	return updateCondition(ctx, k, req, degraded)
+	return updateCondition(ctx, k, req, degraded, MutualExclusionPolicy)
 }
 
-func updateCondition(ctx context.Context, k k8s.Client, req ctrl.Request, condition metav1.Condition) error {
-	var stack lokiv1.LokiStack
-	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil
-		}
-		return kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+// MarkRecovered sets the condition Degraded to false and the condition Ready to true in a single
+// status write. Observers never see the LokiStack neither degraded nor ready in between. All other
+// conditions are preserved.
+func MarkRecovered(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionReady, LocalizableMessage{Key: MessageKeyReady}),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Status:  metav1.ConditionTrue,
 	}
 
-	for _, c := range stack.Status.Conditions {
-		if c.Type == condition.Type &&
-			c.Reason == condition.Reason &&
-			c.Message == condition.Message &&
-			c.Status == metav1.ConditionTrue {
-			// resource already has desired condition
-			return nil
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		degradedIdx, readyIdx := -1, -1
+		for i, c := range stack.Status.Conditions {
+			switch c.Type {
+			case string(lokiv1.ConditionDegraded):
+				degradedIdx = i
+			case string(lokiv1.ConditionReady):
+				readyIdx = i
+			}
+		}
+
+		degradedActive := degradedIdx >= 0 && stack.Status.Conditions[degradedIdx].Status == metav1.ConditionTrue
+		if !degradedActive && hasActiveCondition(stack.Status.Conditions, ready) {
+			// resource already recovered
+			return false
 		}
+
+		transitionTime := metav1.NewTime(now())
+		if degradedActive {
+			stack.Status.Conditions[degradedIdx].Status = metav1.ConditionFalse
+			stack.Status.Conditions[degradedIdx].LastTransitionTime = transitionTime
+		}
+
+		ready.LastTransitionTime = transitionTime
+		if readyIdx >= 0 {
+			stack.Status.Conditions[readyIdx] = ready
+		} else {
+			stack.Status.Conditions = append(stack.Status.Conditions, ready)
+		}
+
+		return true
+	})
+}
+
+// TransitionReadyToPending flips the condition Ready to false and the condition Pending to true
+// if the LokiStack is currently ready. Unlike SetPendingCondition all other conditions are preserved.
+func TransitionReadyToPending(ctx context.Context, k k8s.Client, req ctrl.Request, reason lokiv1.LokiStackConditionReason, msg string) error {
+	pending := metav1.Condition{
+		Type:    string(lokiv1.ConditionPending),
+		Message: msg,
+		Reason:  string(reason),
+		Status:  metav1.ConditionTrue,
 	}
 
-	condition.Status = metav1.ConditionTrue
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		ready := -1
+		for i, c := range stack.Status.Conditions {
+			if c.Type == string(lokiv1.ConditionReady) && c.Status == metav1.ConditionTrue {
+				ready = i
+			}
+		}
 
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
-			return err
+		if ready == -1 {
+			// nothing to downgrade
+			return false
 		}
 
-		now := metav1.Now()
-		condition.LastTransitionTime = now
+		transitionTime := metav1.NewTime(now())
+		stack.Status.Conditions[ready].Status = metav1.ConditionFalse
+		stack.Status.Conditions[ready].LastTransitionTime = transitionTime
 
-		index := -1
-		for i := range stack.Status.Conditions {
-			// Reset all other conditions first
-			stack.Status.Conditions[i].Status = metav1.ConditionFalse
-			stack.Status.Conditions[i].LastTransitionTime = now
+		pending.LastTransitionTime = transitionTime
+		for i, c := range stack.Status.Conditions {
+			if c.Type == pending.Type {
+				stack.Status.Conditions[i] = pending
+				return true
+			}
+		}
 
-			// Locate existing pending condition if any
-			if stack.Status.Conditions[i].Type == condition.Type {
-				index = i
+		stack.Status.Conditions = append(stack.Status.Conditions, pending)
+		return true
+	})
+}
+
+func updateCondition(ctx context.Context, k k8s.Client, req ctrl.Request, condition metav1.Condition, policy ConditionPolicy) error {
+	condition.Status = metav1.ConditionTrue
+	recordAssertion(req.NamespacedName, condition.Type, now())
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		return setStackCondition(stack, condition, policy)
+	})
+}
+
+// setStackCondition applies the condition to the lokistack status conditions according to the
+// policy keeping the pinned conditions, and prunes Status.Warnings if the policy reset Warning.
+func setStackCondition(stack *lokiv1.LokiStack, condition metav1.Condition, policy ConditionPolicy) bool {
+	changed := setCondition(lokiStackConditions(stack), condition, policy, metav1.NewTime(now()), pinnedConditions(stack))
+	return pruneWarnings(stack) || changed
+}
+
+// lokiStackConditions is the ConditionsFunc of LokiStacks.
+func lokiStackConditions(stack *lokiv1.LokiStack) *[]metav1.Condition {
+	return &stack.Status.Conditions
+}
+
+// clearCondition sets the condition of the given type to false if it is active
+// for the given reason and not pinned. All other conditions are left untouched.
+func clearCondition(ctx context.Context, k k8s.Client, req ctrl.Request, conditionType lokiv1.LokiStackConditionType, reason lokiv1.LokiStackConditionReason) error {
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		if isPinned(stack, string(conditionType), string(reason)) {
+			return false
+		}
+		for i, c := range stack.Status.Conditions {
+			if c.Type == string(conditionType) && c.Reason == string(reason) && c.Status == metav1.ConditionTrue {
+				stack.Status.Conditions[i].Status = metav1.ConditionFalse
+				stack.Status.Conditions[i].LastTransitionTime = metav1.NewTime(now())
+				return true
 			}
 		}
 
-		if index == -1 {
-			stack.Status.Conditions = append(stack.Status.Conditions, condition)
-		} else {
-			stack.Status.Conditions[index] = condition
+		return false
+	})
+}
+
+// updateStatus looks up the LokiStack and applies mutate on it. The status is written
+// only if mutate reports a change. Conflicting and throttled writes are retried with a
+// fresh copy of the LokiStack. Within a context returned by DeferStatusWrites mutate is
+// accumulated and applied on FlushStatus instead. The lookup and the write are shared with
+// the other custom resources through updateStatusOn. LokiStacks not owned by this operator
+// are never written and return ErrNotOwned.
+func updateStatus(ctx context.Context, k k8s.Client, req ctrl.Request, mutate func(*lokiv1.LokiStack) bool) error {
+	if options.Disabled {
+		return nil
+	}
+
+	if deferWrite(ctx, k, req, mutate) {
+		return nil
+	}
+
+	var (
+		previous []metav1.Condition
+		foreign  bool
+		write    func(context.Context, *lokiv1.LokiStack, *lokiv1.LokiStack) error
+	)
+	if options.PatchStatus {
+		write = patchStatus(k)
+	}
+	stack, err := updateStatusOn[lokiv1.LokiStack](ctx, k, req.NamespacedName, func(stack *lokiv1.LokiStack) bool {
+		if foreign = !owns(stack); foreign {
+			return false
 		}
 
-		return k.Status().Update(ctx, &stack)
+		previous = append([]metav1.Condition{}, stack.Status.Conditions...)
+		if !mutate(stack) {
+			return false
+		}
+
+		// The derived fields are stamped only along an actual change, so that
+		// a version bump alone never causes a status update.
+		stampDerivedStatus(req.NamespacedName, stack)
+		return true
+	}, statusHooks[*lokiv1.LokiStack]{
+		noop: func() {
+			if !foreign {
+				metrics.IncStatusNoop(req.NamespacedName)
+			}
+		},
+		written: func(stack *lokiv1.LokiStack) {
+			recordOwnChange(req.NamespacedName, stack.ResourceVersion)
+			recordWrite(req.NamespacedName)
+		},
+		done: func(err error, d time.Duration) {
+			switch {
+			case err == nil:
+				metrics.ObserveStatusUpdateDuration(metrics.StatusUpdateSuccess, d)
+			case apierrors.IsConflict(err):
+				metrics.ObserveStatusUpdateDuration(metrics.StatusUpdateConflict, d)
+			default:
+				metrics.ObserveStatusUpdateDuration(metrics.StatusUpdateError, d)
+			}
+		},
+		write: write,
 	})
+	if foreign {
+		return notOwnedError(req)
+	}
+	if err != nil || stack == nil {
+		return err
+	}
+
+	auditTransitions(ctx, req, previous, stack.Status.Conditions)
+	changes := conditionChanges(req.NamespacedName, previous, stack.Status.Conditions)
+	publish(changes)
+	sinkTransitions(ctx, changes)
+	recordTransition(req.NamespacedName, previous, stack.Status.Conditions, now())
+
+	return nil
+}
+
+// stampDerivedStatus sets the status fields derived from the conditions of the LokiStack, i.e.
+// the operator version, the conditions hash, the phase message, the runbook URL and the message
+// keys. They are part of the same status write as the conditions, since any write of the LokiStack
+// metadata triggers another reconciliation.
+func stampDerivedStatus(key types.NamespacedName, stack *lokiv1.LokiStack) {
+	stack.Status.OperatorVersion = version.Version
+	stack.Status.ConditionsHash = StatusHash(stack)
+	stack.Status.Message = statusMessage(stack)
+	stack.Status.RunbookURL = runbookURL(stack)
+	stack.Status.ConditionMessageKeys = conditionMessageKeys(key, stack)
+}
+
+// hasActiveCondition returns true if the conditions contain the given condition
+// with the same reason and message set to true.
+func hasActiveCondition(conditions []metav1.Condition, condition metav1.Condition) bool {
+	for _, c := range conditions {
+		if c.Type == condition.Type &&
+			c.Reason == condition.Reason &&
+			c.Message == condition.Message &&
+			c.Status == metav1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
 }
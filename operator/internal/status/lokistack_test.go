@@ -3,9 +3,11 @@ package status
 import (
 	"context"
 	"testing"
+	"time"
 
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
 	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+	"github.com/grafana/loki/operator/internal/version"
 
 	"github.com/stretchr/testify/require"
 
@@ -17,6 +19,37 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+func setupFakes(stack *lokiv1.LokiStack) (*k8sfakes.FakeClient, *k8sfakes.FakeStatusWriter) {
+	sw := &k8sfakes.FakeStatusWriter{}
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		if name.Name == stack.Name && name.Namespace == stack.Namespace {
+			k.SetClientObject(object, stack)
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+	k.StatusStub = func() client.StatusWriter { return sw }
+
+	return k, sw
+}
+
+// listedWarnings returns the Status.Warnings matching the active Warning conditions.
+func listedWarnings(component string, conditions ...metav1.Condition) []lokiv1.LokiStackWarning {
+	var warnings []lokiv1.LokiStackWarning
+	for _, c := range conditions {
+		if c.Type == string(lokiv1.ConditionWarning) && c.Status == metav1.ConditionTrue {
+			warnings = append(warnings, lokiv1.LokiStackWarning{
+				Reason:    lokiv1.LokiStackConditionReason(c.Reason),
+				Component: component,
+				Message:   c.Message,
+			})
+		}
+	}
+
+	return warnings
+}
+
 func setupFakesNoError(t *testing.T, stack *lokiv1.LokiStack) (*k8sfakes.FakeClient, *k8sfakes.FakeStatusWriter) {
 	sw := &k8sfakes.FakeStatusWriter{}
 	k := &k8sfakes.FakeClient{}
@@ -537,3 +570,373 @@ func TestSetDegradedCondition_WhenNoneExisting_AppendDegradedCondition(t *testin
 	require.NotZero(t, k.StatusCallCount())
 	require.NotZero(t, sw.UpdateCallCount())
 }
+
+func TestTransitionReadyToPending_WhenNotReady_DoNothing(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionPending),
+					Reason:  string(lokiv1.ReasonPendingComponents),
+					Message: messagePending,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, _ := setupFakesNoError(t, &s)
+
+	err := TransitionReadyToPending(context.Background(), k, r, lokiv1.ReasonPendingComponents, "waiting on dependency")
+	require.NoError(t, err)
+	require.Zero(t, k.StatusCallCount())
+}
+
+func TestTransitionReadyToPending_PreservesOtherConditions(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReady),
+					Reason:  string(lokiv1.ReasonReadyComponents),
+					Message: messageReady,
+					Status:  metav1.ConditionTrue,
+				},
+				{
+					Type:    string(lokiv1.ConditionDegraded),
+					Reason:  string(lokiv1.ReasonMissingRulerSecret),
+					Message: "Missing ruler remote write authorization secret",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakesNoError(t, &s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		actual := obj.(*lokiv1.LokiStack)
+		require.Len(t, actual.Status.Conditions, 3)
+
+		ready := actual.Status.Conditions[0]
+		require.Equal(t, string(lokiv1.ConditionReady), ready.Type)
+		require.Equal(t, metav1.ConditionFalse, ready.Status)
+
+		degraded := actual.Status.Conditions[1]
+		require.Equal(t, string(lokiv1.ConditionDegraded), degraded.Type)
+		require.Equal(t, metav1.ConditionTrue, degraded.Status)
+
+		pending := actual.Status.Conditions[2]
+		require.Equal(t, string(lokiv1.ConditionPending), pending.Type)
+		require.Equal(t, metav1.ConditionTrue, pending.Status)
+		require.Equal(t, "waiting on dependency", pending.Message)
+		return nil
+	}
+
+	err := TransitionReadyToPending(context.Background(), k, r, lokiv1.ReasonPendingComponents, "waiting on dependency")
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestDegradedError_ConditionMessage(t *testing.T) {
+	err := &DegradedError{
+		Message: "Missing object storage secret",
+		Reason:  lokiv1.ReasonMissingObjectStorageSecret,
+	}
+	require.Equal(t, "Missing object storage secret", err.ConditionMessage())
+
+	err.Remediation = "Create secret my-secret in namespace some-ns"
+	require.Equal(t, "Missing object storage secret (remediation: Create secret my-secret in namespace some-ns)", err.ConditionMessage())
+}
+
+func TestSetReadyCondition_PreservesExternallyOwnedConditions(t *testing.T) {
+	foreign := metav1.Condition{
+		Type:    "policy.example.com/Compliant",
+		Reason:  "PolicySatisfied",
+		Message: "All policies satisfied",
+		Status:  metav1.ConditionTrue,
+	}
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionPending),
+					Reason:  string(lokiv1.ReasonPendingComponents),
+					Message: messagePending,
+					Status:  metav1.ConditionTrue,
+				},
+				foreign,
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		actual := obj.(*lokiv1.LokiStack)
+		require.Len(t, actual.Status.Conditions, 3)
+		require.Equal(t, metav1.ConditionFalse, actual.Status.Conditions[0].Status)
+		require.Equal(t, foreign, actual.Status.Conditions[1])
+		require.Equal(t, string(lokiv1.ConditionReady), actual.Status.Conditions[2].Type)
+		require.Equal(t, metav1.ConditionTrue, actual.Status.Conditions[2].Status)
+		return nil
+	}
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestSetFailedConditionWithRequeue_ReturnsCappedRequeueAfter(t *testing.T) {
+	table := []struct {
+		name  string
+		after time.Duration
+		want  time.Duration
+	}{
+		{
+			name:  "below cap",
+			after: 30 * time.Second,
+			want:  30 * time.Second,
+		},
+		{
+			name:  "above cap",
+			after: time.Hour,
+			want:  maxFailedRequeueAfter,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakesNoError(t, &s)
+
+			res, err := SetFailedConditionWithRequeue(context.Background(), k, r, "ingester crashlooping", lokiv1.ReasonFailedComponents, tc.after)
+			require.NoError(t, err)
+			require.Equal(t, ctrl.Result{RequeueAfter: tc.want}, res)
+			require.Equal(t, 1, sw.UpdateCallCount())
+		})
+	}
+}
+
+func TestSetFailedConditionWithRequeue_WhenUpdateFails_ReturnError(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+		return apierrors.NewBadRequest("something went wrong")
+	}
+
+	res, err := SetFailedConditionWithRequeue(context.Background(), k, r, "ingester crashlooping", lokiv1.ReasonFailedComponents, time.Minute)
+	require.Error(t, err)
+	require.Equal(t, ctrl.Result{}, res)
+}
+
+func TestMarkRecovered_ClearsDegradedAndSetsReadyInSingleWrite(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(lokiv1.ConditionDegraded),
+					Reason: string(lokiv1.ReasonMissingObjectStorageSecret),
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   string(lokiv1.ConditionReady),
+					Reason: string(lokiv1.ReasonReadyComponents),
+					Status: metav1.ConditionFalse,
+				},
+				{
+					Type:   string(lokiv1.ConditionWarning),
+					Reason: string(lokiv1.ReasonWALDiskPressure),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		actual := obj.(*lokiv1.LokiStack)
+		require.Len(t, actual.Status.Conditions, 3)
+
+		m := ConditionsMap(actual)
+		require.Equal(t, metav1.ConditionFalse, m[string(lokiv1.ConditionDegraded)].Status)
+		require.Equal(t, metav1.ConditionTrue, m[string(lokiv1.ConditionReady)].Status)
+		require.Equal(t, metav1.ConditionTrue, m[string(lokiv1.ConditionWarning)].Status)
+		return nil
+	}
+
+	err := MarkRecovered(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestMarkRecovered_WhenAlreadyReady_DoNothing(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReady),
+					Reason:  string(lokiv1.ReasonReadyComponents),
+					Message: messageReady,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+
+	err := MarkRecovered(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Zero(t, sw.UpdateCallCount())
+}
+
+func TestUpdateCondition_StampsOperatorVersionOnWrite(t *testing.T) {
+	previous := version.Version
+	version.Version = "v1.2.3"
+	t.Cleanup(func() { version.Version = previous })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		require.Equal(t, "v1.2.3", obj.(*lokiv1.LokiStack).Status.OperatorVersion)
+		return nil
+	}
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+	require.Zero(t, k.UpdateCallCount())
+}
+
+func TestUpdateCondition_WhenVersionChangedOnly_DoNothing(t *testing.T) {
+	previous := version.Version
+	version.Version = "v1.2.4"
+	t.Cleanup(func() { version.Version = previous })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			OperatorVersion: "v1.2.3",
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReady),
+					Reason:  string(lokiv1.ReasonReadyComponents),
+					Message: messageReady,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Zero(t, sw.UpdateCallCount())
+	require.Zero(t, k.UpdateCallCount())
+}
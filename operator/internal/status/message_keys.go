@@ -0,0 +1,117 @@
+package status
+
+import (
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MessageKey is the stable identifier of a condition message independent of its wording.
+// The keys of the active conditions are stamped into Status.ConditionMessageKeys on every
+// status write, e.g. {"Failed":{"key":"lokistack.failedPods","params":{"pods":"ingester-0"}}}.
+// External layers translate the condition messages by their key instead of the English
+// wording, which may change between operator versions.
+type MessageKey string
+
+const (
+	// MessageKeyReady identifies the message of the condition Ready.
+	MessageKeyReady MessageKey = "lokistack.ready"
+	// MessageKeyPending identifies the message of the condition Pending.
+	MessageKeyPending MessageKey = "lokistack.pending"
+	// MessageKeyPendingPods identifies the message of the condition Pending listing the param "pods".
+	MessageKeyPendingPods MessageKey = "lokistack.pendingPods"
+	// MessageKeyFailed identifies the message of the condition Failed.
+	MessageKeyFailed MessageKey = "lokistack.failed"
+	// MessageKeyFailedPods identifies the message of the condition Failed listing the param "pods".
+	MessageKeyFailedPods MessageKey = "lokistack.failedPods"
+	// MessageKeyRecovering identifies the message of the condition Recovering.
+	MessageKeyRecovering MessageKey = "lokistack.recovering"
+	// MessageKeyReadOnly identifies the message of the condition ReadOnly.
+	MessageKeyReadOnly MessageKey = "lokistack.readOnly"
+)
+
+// englishMessages holds the English rendering of the message keys. Params are referenced
+// as {name}.
+var englishMessages = map[MessageKey]string{
+	MessageKeyReady:       messageReady,
+	MessageKeyPending:     messagePending,
+	MessageKeyPendingPods: messagePending + ": {pods}",
+	MessageKeyFailed:      messageFailed,
+	MessageKeyFailedPods:  messageFailed + ": {pods}",
+	MessageKeyRecovering:  messageRecovering,
+	MessageKeyReadOnly:    messageReadOnly,
+}
+
+// LocalizableMessage is a condition message identified by a stable key and its parameters.
+type LocalizableMessage struct {
+	Key    MessageKey        `json:"key"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// English returns the default English rendering of the message.
+func (m LocalizableMessage) English() string {
+	names := make([]string, 0, len(m.Params))
+	for name := range m.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var oldnew []string
+	for _, name := range names {
+		oldnew = append(oldnew, "{"+name+"}", m.Params[name])
+	}
+
+	return strings.NewReplacer(oldnew...).Replace(englishMessages[m.Key])
+}
+
+// podsMessage returns the message with the key listing the pods or the key without pods.
+func podsMessage(key, podsKey MessageKey, pods []string) LocalizableMessage {
+	if len(pods) == 0 {
+		return LocalizableMessage{Key: key}
+	}
+
+	return LocalizableMessage{Key: podsKey, Params: map[string]string{"pods": podList(pods)}}
+}
+
+// messageKeys holds the last localizable message per condition type of the LokiStacks.
+var messageKeys = newStackStore[map[string]LocalizableMessage]()
+
+// keyedMessage records the localizable message for the condition type of the LokiStack and
+// returns its English rendering for the condition message.
+func keyedMessage(key types.NamespacedName, conditionType lokiv1.LokiStackConditionType, m LocalizableMessage) string {
+	messageKeys.update(key, func(keys *map[string]LocalizableMessage) {
+		if *keys == nil {
+			*keys = map[string]LocalizableMessage{}
+		}
+		(*keys)[string(conditionType)] = m
+	})
+
+	return m.English()
+}
+
+// conditionMessageKeys returns the localizable messages of the active conditions of the
+// LokiStack whose message is the English rendering of the recorded message or nil if there
+// are none. Conditions with a message not rendered from a key, e.g. the messages of degraded
+// errors, are omitted.
+func conditionMessageKeys(key types.NamespacedName, stack *lokiv1.LokiStack) map[string]lokiv1.LokiStackConditionMessageKey {
+	var active map[string]lokiv1.LokiStackConditionMessageKey
+	messageKeys.read(key, func(keys *map[string]LocalizableMessage) {
+		for _, c := range stack.Status.Conditions {
+			m, ok := (*keys)[c.Type]
+			if !ok || c.Status != metav1.ConditionTrue || c.Message != m.English() {
+				continue
+			}
+
+			if active == nil {
+				active = map[string]lokiv1.LokiStackConditionMessageKey{}
+			}
+			active[c.Type] = lokiv1.LokiStackConditionMessageKey{Key: string(m.Key), Params: m.Params}
+		}
+	})
+
+	return active
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpdateCondition_StampsConditionMessageKeys(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	messageKeys.delete(r.NamespacedName)
+	t.Cleanup(func() { messageKeys.delete(r.NamespacedName) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Status = obj.(*lokiv1.LokiStack).Status
+		return nil
+	}
+
+	err := SetFailedCondition(context.Background(), k, r, "ingester-1", "ingester-0")
+	require.NoError(t, err)
+	require.Equal(t, "Some LokiStack components failed: ingester-0, ingester-1", ConditionsMap(&s)[string(lokiv1.ConditionFailed)].Message)
+	require.Equal(t, map[string]lokiv1.LokiStackConditionMessageKey{
+		"Failed": lokiv1.LokiStackConditionMessageKey{Key: "lokistack.failedPods", Params: map[string]string{"pods": "ingester-0, ingester-1"}},
+	}, s.Status.ConditionMessageKeys)
+
+	err = SetReadOnlyCondition(context.Background(), k, r, true)
+	require.NoError(t, err)
+	require.Equal(t, map[string]lokiv1.LokiStackConditionMessageKey{
+		"Failed":   lokiv1.LokiStackConditionMessageKey{Key: "lokistack.failedPods", Params: map[string]string{"pods": "ingester-0, ingester-1"}},
+		"ReadOnly": {Key: "lokistack.readOnly"},
+	}, s.Status.ConditionMessageKeys)
+
+	// Messages of degraded errors have no key
+	err = SetDegradedCondition(context.Background(), k, r, "Missing object storage secret", lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.Equal(t, map[string]lokiv1.LokiStackConditionMessageKey{
+		"ReadOnly": {Key: "lokistack.readOnly"},
+	}, s.Status.ConditionMessageKeys)
+
+	err = SetReadOnlyCondition(context.Background(), k, r, false)
+	require.NoError(t, err)
+	require.Empty(t, s.Status.ConditionMessageKeys)
+}
+
+func TestConditionMessageKeys_StableAcrossWordingChanges(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	messageKeys.delete(r.NamespacedName)
+	t.Cleanup(func() { messageKeys.delete(r.NamespacedName) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Status = obj.(*lokiv1.LokiStack).Status
+		return nil
+	}
+
+	err := SetPendingCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, messagePending, ConditionsMap(&s)[string(lokiv1.ConditionPending)].Message)
+	require.Equal(t, map[string]lokiv1.LokiStackConditionMessageKey{
+		"Pending": {Key: "lokistack.pending"},
+	}, s.Status.ConditionMessageKeys)
+
+	// A new operator version rewords the message
+	original := englishMessages[MessageKeyPending]
+	englishMessages[MessageKeyPending] = "Waiting for LokiStack components to start"
+	t.Cleanup(func() { englishMessages[MessageKeyPending] = original })
+
+	err = SetPendingCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, "Waiting for LokiStack components to start", ConditionsMap(&s)[string(lokiv1.ConditionPending)].Message)
+	require.Equal(t, map[string]lokiv1.LokiStackConditionMessageKey{
+		"Pending": {Key: "lokistack.pending"},
+	}, s.Status.ConditionMessageKeys)
+}
+
+func TestLocalizableMessage_English(t *testing.T) {
+	pods := []string{"querier-5", "querier-4", "querier-3", "querier-2", "querier-1", "querier-0"}
+
+	require.Equal(t, messagePending, podsMessage(MessageKeyPending, MessageKeyPendingPods, nil).English())
+	require.Equal(t, withPods(messagePending, pods), podsMessage(MessageKeyPending, MessageKeyPendingPods, pods).English())
+	require.Equal(t, withPods(messageFailed, pods), podsMessage(MessageKeyFailed, MessageKeyFailedPods, pods).English())
+}
@@ -0,0 +1,88 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MessageValues defines the named values used to render a condition message template.
+type MessageValues map[string]string
+
+// messageTemplates maps condition reasons to the template rendering their message.
+// Reasons without a template use the raw message provided by the caller.
+var messageTemplates = map[lokiv1.LokiStackConditionReason]*template.Template{
+	lokiv1.ReasonMissingObjectStorageCAConfigMap: newMessageTemplate(lokiv1.ReasonMissingObjectStorageCAConfigMap, "Missing object storage CA config map: {{.name}}"),
+	lokiv1.ReasonMissingGatewayTenantSecret:      newMessageTemplate(lokiv1.ReasonMissingGatewayTenantSecret, "Missing secrets for tenant {{.tenant}}"),
+}
+
+func newMessageTemplate(reason lokiv1.LokiStackConditionReason, text string) *template.Template {
+	return template.Must(template.New(string(reason)).Option("missingkey=error").Parse(text))
+}
+
+// FormatMessage renders the message template registered for the reason with the given values.
+// It returns an error if no template is registered for the reason or any value used by the
+// template is missing. Callers with free-text messages can still set them directly on the
+// condition or the DegradedError.
+func FormatMessage(reason lokiv1.LokiStackConditionReason, values MessageValues) (string, error) {
+	tmpl, ok := messageTemplates[reason]
+	if !ok {
+		return "", kverrors.New("no message template for condition reason", "reason", reason)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, values); err != nil {
+		return "", kverrors.Wrap(err, "failed to render condition message", "reason", reason)
+	}
+
+	return sb.String(), nil
+}
+
+// FormatMap renders the entries of the map sorted by key, each formatted with the given
+// format taking the key and the value, and joined by ", ". Building condition messages
+// from maps with this helper keeps them stable across reconciles, since map iteration
+// order is random and would otherwise cause a status update on every reconcile.
+func FormatMap(values map[string]string, format string) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, fmt.Sprintf(format, k, values[k]))
+	}
+
+	return strings.Join(entries, ", ")
+}
+
+// shortMessageLength is the maximum length in characters of a short condition message.
+const shortMessageLength = 40
+
+// ShortConditionMessage derives a display message of at most 40 characters from the condition
+// for printer columns, e.g. in `kubectl get`, while the condition keeps its full message.
+// Whitespace runs including line breaks are collapsed into single spaces. Longer messages are
+// cut on a character boundary and end with "...". Conditions without a message fall back to
+// their reason.
+func ShortConditionMessage(condition metav1.Condition) string {
+	msg := strings.Join(strings.Fields(condition.Message), " ")
+	if msg == "" {
+		msg = condition.Reason
+	}
+
+	runes := []rune(msg)
+	if len(runes) <= shortMessageLength {
+		return msg
+	}
+
+	const ellipsis = "..."
+	cut := strings.TrimRight(string(runes[:shortMessageLength-len(ellipsis)]), " ,.:;")
+	return cut + ellipsis
+}
@@ -0,0 +1,111 @@
+package status_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFormatMessage(t *testing.T) {
+	msg, err := status.FormatMessage(lokiv1.ReasonMissingGatewayTenantSecret, status.MessageValues{
+		"tenant": "application",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Missing secrets for tenant application", msg)
+}
+
+func TestFormatMessage_WhenMissingValue_ReturnError(t *testing.T) {
+	_, err := status.FormatMessage(lokiv1.ReasonMissingObjectStorageCAConfigMap, status.MessageValues{})
+	require.Error(t, err)
+}
+
+func TestFormatMessage_WhenNoTemplate_ReturnError(t *testing.T) {
+	_, err := status.FormatMessage(lokiv1.ReasonReadyComponents, status.MessageValues{})
+	require.Error(t, err)
+}
+
+func TestFormatMap_IsDeterministic(t *testing.T) {
+	values := map[string]string{
+		"querier":        "Failed",
+		"compactor":      "Pending",
+		"ingester":       "Failed",
+		"distributor":    "Unknown",
+		"index-gateway":  "Pending",
+		"query-frontend": "Failed",
+	}
+
+	want := "compactor: Pending, distributor: Unknown, index-gateway: Pending, ingester: Failed, querier: Failed, query-frontend: Failed"
+	for i := 0; i < 100; i++ {
+		require.Equal(t, want, status.FormatMap(values, "%s: %s"))
+	}
+}
+
+func TestFormatMap_WhenEmpty_ReturnEmpty(t *testing.T) {
+	require.Empty(t, status.FormatMap(nil, "%s (%s)"))
+}
+
+func TestShortConditionMessage(t *testing.T) {
+	table := []struct {
+		name      string
+		condition metav1.Condition
+		want      string
+	}{
+		{
+			name:      "short message",
+			condition: metav1.Condition{Reason: string(lokiv1.ReasonReadyComponents), Message: "All components ready"},
+			want:      "All components ready",
+		},
+		{
+			name:      "exactly the limit",
+			condition: metav1.Condition{Message: "0123456789012345678901234567890123456789"},
+			want:      "0123456789012345678901234567890123456789",
+		},
+		{
+			name:      "one over the limit",
+			condition: metav1.Condition{Message: "01234567890123456789012345678901234567890"},
+			want:      "0123456789012345678901234567890123456...",
+		},
+		{
+			name:      "cut long message",
+			condition: metav1.Condition{Message: "Missing object storage secret, check the storage secret name"},
+			want:      "Missing object storage secret, check...",
+		},
+		{
+			name:      "trim separator before ellipsis",
+			condition: metav1.Condition{Message: "012345678901234567890123456789012345, more details"},
+			want:      "012345678901234567890123456789012345...",
+		},
+		{
+			name:      "collapse whitespace",
+			condition: metav1.Condition{Message: "  Some pods\n\tare failing  "},
+			want:      "Some pods are failing",
+		},
+		{
+			name:      "multi-byte characters",
+			condition: metav1.Condition{Message: "Größenbeschränkung überschritten für Komponente äöü"},
+			want:      "Größenbeschränkung überschritten für...",
+		},
+		{
+			name:      "empty message falls back to reason",
+			condition: metav1.Condition{Reason: string(lokiv1.ReasonPendingComponents)},
+			want:      "PendingComponents",
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := status.ShortConditionMessage(tc.condition)
+			require.Equal(t, tc.want, got)
+			require.LessOrEqual(t, len([]rune(got)), 40)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	conditionMetricName = "lokistack_status_condition"
+	conditionMetricHelp = "LokiStack status conditions, 1 if the condition status is true, 0 otherwise"
+)
+
+// labelValueEscaper escapes label values as required by the OpenMetrics text format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// RenderConditionsOpenMetrics renders the status conditions of the given LokiStacks as a single
+// OpenMetrics text exposition with one gauge sample per stack and condition. Samples are sorted
+// by stack namespace, name and condition type, thus the output is stable for the same input.
+func RenderConditionsOpenMetrics(stacks []lokiv1.LokiStack) string {
+	sorted := append([]lokiv1.LokiStack{}, stacks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# TYPE %s gauge\n", conditionMetricName)
+	fmt.Fprintf(&sb, "# HELP %s %s\n", conditionMetricName, conditionMetricHelp)
+
+	for _, stack := range sorted {
+		conditions := append([]metav1.Condition{}, stack.Status.Conditions...)
+		sort.Slice(conditions, func(i, j int) bool {
+			return conditions[i].Type < conditions[j].Type
+		})
+
+		for _, c := range conditions {
+			value := 0
+			if c.Status == metav1.ConditionTrue {
+				value = 1
+			}
+
+			fmt.Fprintf(&sb, "%s{namespace=\"%s\",name=\"%s\",type=\"%s\",reason=\"%s\"} %d\n",
+				conditionMetricName,
+				labelValueEscaper.Replace(stack.Namespace),
+				labelValueEscaper.Replace(stack.Name),
+				labelValueEscaper.Replace(c.Type),
+				labelValueEscaper.Replace(c.Reason),
+				value,
+			)
+		}
+	}
+
+	sb.WriteString("# EOF\n")
+	return sb.String()
+}
@@ -0,0 +1,65 @@
+package status_test
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderConditionsOpenMetrics(t *testing.T) {
+	stacks := []lokiv1.LokiStack{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "ns"},
+			Status: lokiv1.LokiStackStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   string(lokiv1.ConditionReady),
+						Reason: string(lokiv1.ReasonReadyComponents),
+						Status: metav1.ConditionTrue,
+					},
+					{
+						Type:   string(lokiv1.ConditionPending),
+						Reason: string(lokiv1.ReasonPendingComponents),
+						Status: metav1.ConditionFalse,
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "ns"},
+			Status: lokiv1.LokiStackStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:   "example.com/Custom",
+						Reason: "quote \" backslash \\ newline \n",
+						Status: metav1.ConditionUnknown,
+					},
+				},
+			},
+		},
+	}
+
+	want := `# TYPE lokistack_status_condition gauge
+# HELP lokistack_status_condition LokiStack status conditions, 1 if the condition status is true, 0 otherwise
+lokistack_status_condition{namespace="ns",name="first",type="example.com/Custom",reason="quote \" backslash \\ newline \n"} 0
+lokistack_status_condition{namespace="ns",name="second",type="Pending",reason="PendingComponents"} 0
+lokistack_status_condition{namespace="ns",name="second",type="Ready",reason="ReadyComponents"} 1
+# EOF
+`
+
+	require.Equal(t, want, status.RenderConditionsOpenMetrics(stacks))
+}
+
+func TestRenderConditionsOpenMetrics_WhenNoStacks_RenderEmptyFamily(t *testing.T) {
+	want := `# TYPE lokistack_status_condition gauge
+# HELP lokistack_status_condition LokiStack status conditions, 1 if the condition status is true, 0 otherwise
+# EOF
+`
+
+	require.Equal(t, want, status.RenderConditionsOpenMetrics(nil))
+}
@@ -0,0 +1,146 @@
+package status
+
+import (
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"k8s.io/utils/clock"
+)
+
+// Options defines the package-wide settings used when evaluating and writing
+// LokiStack status conditions.
+type Options struct {
+	// Disabled turns all status writes of this package into no-ops returning nil.
+	// While disabled the LokiStack status does not reflect the actual state of
+	// the deployment.
+	Disabled bool
+
+	// PatchStatus writes only the changed status fields, e.g. the conditions, as a JSON merge
+	// patch instead of updating the whole status. This reduces the payload for LokiStacks with
+	// large component or tenant status maps.
+	PatchStatus bool
+
+	// WALPressure defines the write ahead log disk usage thresholds.
+	WALPressure Thresholds
+
+	// ClockSkew defines the clock skew thresholds among the LokiStack components.
+	ClockSkew DurationThresholds
+
+	// QueryQueueDepthThreshold is the query scheduler queue depth from which on the condition
+	// Warning reports a saturated query path. Zero disables the depth check.
+	QueryQueueDepthThreshold int
+
+	// ConflictDetection defines the threshold for reporting a possible conflicting controller.
+	ConflictDetection ConflictDetection
+
+	// FlappingDetection defines the threshold for reporting the condition Flapping.
+	FlappingDetection FlappingDetection
+
+	// IssueSprawlThreshold is the number of distinct Degraded and Failed conditions active on the
+	// LokiStack and its tenants above which the condition Warning summarizes them. Zero disables
+	// the summary.
+	IssueSprawlThreshold int
+
+	// RequireSchemaChangeApproval keeps otherwise ready LokiStacks Pending while an upcoming
+	// storage schema change is not approved via the annotation AnnotationSchemaChangeApproved.
+	RequireSchemaChangeApproval bool
+
+	// ConditionTTL is the age from which on Warning and Degraded conditions not re-asserted
+	// are cleared on the next Refresh. Zero disables expiry.
+	ConditionTTL time.Duration
+
+	// AuditConditionTransitions emits a structured audit log line for every actual transition
+	// of a LokiStack condition written by this package. No-op updates are never logged.
+	AuditConditionTransitions bool
+
+	// TransitionSink receives every actual transition of a LokiStack condition written by this
+	// package in order, e.g. to persist them for a post-mortem replay. Nil discards them like
+	// the default NoopTransitionSink.
+	TransitionSink TransitionSink
+
+	// RecoveryWindow is the duration a LokiStack stays Recovering instead of Ready once all
+	// components are ready again after being Degraded or Failed. Zero disables the condition
+	// Recovering, i.e. the LokiStack becomes Ready immediately.
+	RecoveryWindow time.Duration
+
+	// CertExpiryWarningWindow is the duration before the expiry of a certificate from which on
+	// the condition Warning is reported. Zero reports only expired certificates.
+	CertExpiryWarningWindow time.Duration
+
+	// RunbookURLs maps the reasons of the condition Degraded to the URL of their remediation
+	// runbook. The URL of the active Degraded condition is stamped into Status.RunbookURL.
+	// Reasons without a URL and a nil map stamp no URL.
+	RunbookURLs map[lokiv1.LokiStackConditionReason]string
+
+	// DegradedErrorThreshold is the number of consecutive reconciliations failing with a degraded
+	// error from which on the condition Degraded is set. Below the threshold the condition Pending
+	// is set instead, thus a transient error does not degrade the LokiStack. Values below 2 degrade
+	// on the first error.
+	DegradedErrorThreshold int
+
+	// ShutdownTimeout bounds MarkUnknownForAll, so that marking the conditions of many LokiStacks
+	// Unknown never blocks the operator shutdown. Zero relies on the deadline of the context only.
+	ShutdownTimeout time.Duration
+
+	// Owns restricts the status writes of this package to the LokiStacks owned by this operator.
+	// Writes to foreign LokiStacks are skipped with ErrNotOwned. Nil owns all LokiStacks.
+	Owns OwnershipPredicate
+
+	// Clock provides the time used for condition transition times and time-based
+	// evaluations. Tests may replace it with a fake clock. Defaults to real time.
+	Clock clock.PassiveClock
+}
+
+// Thresholds defines the boundaries from which on a measured value is
+// reported as a warning or as degraded.
+type Thresholds struct {
+	// Warning is the value from which on a warning is reported.
+	Warning float64
+	// Degraded is the value from which on the LokiStack is reported degraded.
+	Degraded float64
+}
+
+// DurationThresholds defines the boundaries from which on a measured duration is
+// reported as a warning or as degraded.
+type DurationThresholds struct {
+	// Warning is the duration from which on a warning is reported.
+	Warning time.Duration
+	// Degraded is the duration from which on the LokiStack is reported degraded.
+	Degraded time.Duration
+}
+
+var options = DefaultOptions()
+
+// DefaultOptions returns the options used if Configure is never called.
+func DefaultOptions() Options {
+	return Options{
+		Clock:                   clock.RealClock{},
+		TransitionSink:          NoopTransitionSink{},
+		CertExpiryWarningWindow: 7 * 24 * time.Hour,
+		ShutdownTimeout:         10 * time.Second,
+		WALPressure: Thresholds{
+			Warning:  80,
+			Degraded: 95,
+		},
+		ClockSkew: DurationThresholds{
+			Warning:  time.Second,
+			Degraded: 10 * time.Second,
+		},
+		QueryQueueDepthThreshold: 100,
+	}
+}
+
+// Configure replaces the package-wide options. It is expected to be called once
+// on operator start-up before any reconciliation begins.
+func Configure(o Options) {
+	options = o
+}
+
+// now returns the current time of the configured clock.
+func now() time.Time {
+	if options.Clock == nil {
+		return time.Now()
+	}
+	return options.Clock.Now()
+}
@@ -0,0 +1,95 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigure_WhenDisabled_SkipAllStatusWrites(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Disabled = true
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, _ := setupFakes(&s)
+
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+	require.NoError(t, SetDegradedCondition(context.Background(), k, r, "tell me something", lokiv1.ReasonMissingObjectStorageSecret))
+	require.NoError(t, SetTenantCondition(context.Background(), k, r, "application", lokiv1.ConditionDegraded, "tell me something", lokiv1.ReasonInvalidTenantsConfiguration))
+	require.NoError(t, SetComponentsStatus(context.Background(), k, r))
+	require.NoError(t, SetStorageSchemaStatus(context.Background(), k, r, nil))
+	require.NoError(t, Refresh(context.Background(), k, r))
+
+	require.Zero(t, k.ListCallCount())
+	require.Zero(t, k.StatusCallCount())
+}
+
+func TestConfigure_WithFakeClock_SetDeterministicTransitionTime(t *testing.T) {
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+
+	opts := DefaultOptions()
+	opts.Clock = fakeClock
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(lokiv1.ConditionPending),
+					Reason: string(lokiv1.ReasonPendingComponents),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	want := metav1.NewTime(fakeClock.Now())
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		for _, c := range obj.(*lokiv1.LokiStack).Status.Conditions {
+			require.Equal(t, want, c.LastTransitionTime)
+		}
+		return nil
+	}
+
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
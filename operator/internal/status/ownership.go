@@ -0,0 +1,59 @@
+package status
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ErrNotOwned is returned by the status writes of this package for LokiStacks not owned by
+// this operator according to Options.Owns. The status of the LokiStack is left untouched.
+var ErrNotOwned = errors.New("lokistack not owned by this operator")
+
+// OwnershipPredicate reports whether the LokiStack is owned by this operator, e.g. while
+// migrating LokiStacks between two operators watching the same namespaces.
+type OwnershipPredicate func(stack *lokiv1.LokiStack) bool
+
+// OwnedByLabel returns an OwnershipPredicate for LokiStacks carrying the label key with the
+// given value.
+func OwnedByLabel(key, value string) OwnershipPredicate {
+	return func(stack *lokiv1.LokiStack) bool {
+		v, ok := stack.Labels[key]
+		return ok && v == value
+	}
+}
+
+// owns reports whether the LokiStack is owned by this operator. Without a configured
+// predicate all LokiStacks are owned.
+func owns(stack *lokiv1.LokiStack) bool {
+	return options.Owns == nil || options.Owns(stack)
+}
+
+// IsOwned looks up the LokiStack and reports whether it is owned by this operator. Missing
+// LokiStacks are not owned. Without a configured predicate all LokiStacks are owned without a
+// lookup.
+func IsOwned(ctx context.Context, k k8s.Client, req ctrl.Request) (bool, error) {
+	if options.Owns == nil {
+		return true, nil
+	}
+
+	var stack lokiv1.LokiStack
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+	}
+
+	return owns(&stack), nil
+}
+
+func notOwnedError(req ctrl.Request) error {
+	return kverrors.Wrap(ErrNotOwned, "skipped LokiStack status write", "name", req.NamespacedName)
+}
@@ -0,0 +1,98 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestSetReadyCondition_Ownership(t *testing.T) {
+	table := []struct {
+		name       string
+		owns       OwnershipPredicate
+		labels     map[string]string
+		wantOwned  bool
+		wantUpdate int
+	}{
+		{
+			name:       "no predicate",
+			wantOwned:  true,
+			wantUpdate: 1,
+		},
+		{
+			name:       "owned",
+			owns:       OwnedByLabel("loki.grafana.com/operator", "new"),
+			labels:     map[string]string{"loki.grafana.com/operator": "new"},
+			wantOwned:  true,
+			wantUpdate: 1,
+		},
+		{
+			name:   "foreign",
+			owns:   OwnedByLabel("loki.grafana.com/operator", "new"),
+			labels: map[string]string{"loki.grafana.com/operator": "old"},
+		},
+		{
+			name: "unlabeled",
+			owns: OwnedByLabel("loki.grafana.com/operator", "new"),
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() { Configure(DefaultOptions()) })
+
+			opts := DefaultOptions()
+			opts.Owns = tc.owns
+			Configure(opts)
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+					Labels:    tc.labels,
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+
+			owned, err := IsOwned(context.Background(), k, r)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantOwned, owned)
+			if tc.owns == nil {
+				require.Zero(t, k.GetCallCount())
+			}
+
+			err = SetReadyCondition(context.Background(), k, r)
+			if tc.wantOwned {
+				require.NoError(t, err)
+			} else {
+				require.True(t, errors.Is(err, ErrNotOwned))
+			}
+			require.Equal(t, tc.wantUpdate, sw.UpdateCallCount())
+
+			err = SetDegradedInvolvedObject(context.Background(), k, r, &InvolvedObject{Kind: "Secret", Name: "some-secret"})
+			if tc.wantOwned {
+				require.NoError(t, err)
+			} else {
+				require.True(t, errors.Is(err, ErrNotOwned))
+				require.Zero(t, k.UpdateCallCount())
+			}
+		})
+	}
+}
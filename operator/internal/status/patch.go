@@ -0,0 +1,27 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// patchStatus returns a status write sending only the status fields changed against before as
+// a JSON merge patch, e.g. the conditions, instead of the whole status. The patch carries the
+// resourceVersion of before, thus concurrent writes conflict and are retried as for updates.
+// If the apiserver rejects the patch as unsupported, the status is updated instead.
+func patchStatus(k k8s.Client) func(ctx context.Context, before, stack *lokiv1.LokiStack) error {
+	return func(ctx context.Context, before, stack *lokiv1.LokiStack) error {
+		patch := client.MergeFromWithOptions(before, client.MergeFromWithOptimisticLock{})
+
+		err := k.Status().Patch(ctx, stack, patch)
+		if apierrors.IsMethodNotSupported(err) || apierrors.IsUnsupportedMediaType(err) {
+			return k.Status().Update(ctx, stack)
+		}
+		return err
+	}
+}
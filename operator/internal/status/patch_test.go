@@ -0,0 +1,172 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func patchTestStack(pods int) lokiv1.LokiStack {
+	running := make([]string, 0, pods)
+	for i := 0; i < pods; i++ {
+		running = append(running, fmt.Sprintf("my-stack-ingester-%d", i))
+	}
+
+	return lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-stack",
+			Namespace:       "some-ns",
+			ResourceVersion: "42",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Components: lokiv1.LokiStackComponentStatus{
+				Ingester: lokiv1.PodStatusMap{corev1.PodRunning: running},
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionReady),
+					Reason:             string(lokiv1.ReasonReadyComponents),
+					Message:            messageReady,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Date(2022, 10, 11, 11, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+	}
+}
+
+func TestSetCondition_WhenPatchStatus_SendChangedStatusOnly(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	opts := DefaultOptions()
+	opts.PatchStatus = true
+	opts.Clock = clocktesting.NewFakePassiveClock(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+	Configure(opts)
+
+	s := patchTestStack(3)
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	messageKeys.delete(r.NamespacedName)
+	t.Cleanup(func() { messageKeys.delete(r.NamespacedName) })
+	stampDerivedStatus(r.NamespacedName, &s)
+
+	k, sw := setupFakes(&s)
+	sw.PatchStub = func(_ context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+		require.Equal(t, types.MergePatchType, patch.Type())
+
+		// Only the conditions and the conditions hash changed
+		data, err := patch.Data(obj)
+		require.NoError(t, err)
+		require.JSONEq(t, fmt.Sprintf(`{
+			"metadata": {"resourceVersion": "42"},
+			"status": {
+				"conditionsHash": %q,
+				"conditions": [
+					{
+						"type": "Ready",
+						"status": "True",
+						"reason": "ReadyComponents",
+						"message": "All components ready",
+						"lastTransitionTime": "2022-10-11T11:00:00Z"
+					},
+					{
+						"type": "Warning",
+						"status": "True",
+						"reason": "WALDiskPressure",
+						"message": "some warning",
+						"lastTransitionTime": "2022-10-11T12:00:00Z"
+					}
+				]
+			}
+		}`, StatusHash(obj.(*lokiv1.LokiStack))), string(data))
+		return nil
+	}
+
+	err := SetCondition(context.Background(), k, r, lokiv1.ConditionWarning, "some warning", lokiv1.ReasonWALDiskPressure, CoexistPolicy)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.PatchCallCount())
+	require.Zero(t, sw.UpdateCallCount())
+}
+
+func TestSetCondition_WhenPatchUnsupported_FallbackToUpdate(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	opts := DefaultOptions()
+	opts.PatchStatus = true
+	Configure(opts)
+
+	s := patchTestStack(3)
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.PatchStub = func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+		return apierrors.NewMethodNotSupported(schema.GroupResource{Group: "loki.grafana.com", Resource: "lokistacks"}, "patch")
+	}
+
+	err := SetCondition(context.Background(), k, r, lokiv1.ConditionWarning, "some warning", lokiv1.ReasonWALDiskPressure, CoexistPolicy)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.PatchCallCount())
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func BenchmarkStatusWritePayload(b *testing.B) {
+	before := patchTestStack(100)
+	after := before.DeepCopy()
+	after.Status.Conditions = MutualExclusionPolicy.apply(after.Status.Conditions, metav1.Condition{
+		Type:    string(lokiv1.ConditionPending),
+		Reason:  string(lokiv1.ReasonPendingComponents),
+		Message: messagePending,
+		Status:  metav1.ConditionTrue,
+	}, metav1.NewTime(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)), nil)
+
+	b.Run("update", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			data, err := json.Marshal(after)
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "payload-bytes")
+	})
+
+	b.Run("patch", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			patch := client.MergeFromWithOptions(&before, client.MergeFromWithOptimisticLock{})
+			data, err := patch.Data(after)
+			if err != nil {
+				b.Fatal(err)
+			}
+			size = len(data)
+		}
+		b.ReportMetric(float64(size), "payload-bytes")
+	})
+}
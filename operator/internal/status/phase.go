@@ -0,0 +1,75 @@
+package status
+
+import (
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// phasePrecedence orders the condition types representing a LokiStack phase from the
+// highest to the lowest priority.
+var phasePrecedence = []lokiv1.LokiStackConditionType{
+	lokiv1.ConditionFailed,
+	lokiv1.ConditionDegraded,
+	lokiv1.ConditionPending,
+	lokiv1.ConditionRecovering,
+	lokiv1.ConditionReady,
+}
+
+// Phase returns the type of the highest-priority active condition of the LokiStack
+// according to phasePrecedence. It returns false if none of these conditions is active.
+func Phase(stack *lokiv1.LokiStack) (lokiv1.LokiStackConditionType, bool) {
+	c, ok := phaseCondition(stack)
+	if !ok {
+		return "", false
+	}
+
+	return lokiv1.LokiStackConditionType(c.Type), true
+}
+
+// NotReadyReason returns the reason of the highest-priority active condition according to
+// the precedence of Phase if the LokiStack is not ready. It returns false if the LokiStack
+// is ready. A LokiStack without any active phase condition is not ready for an empty reason.
+func NotReadyReason(stack *lokiv1.LokiStack) (string, bool) {
+	c, ok := phaseCondition(stack)
+	if !ok {
+		return "", true
+	}
+
+	if c.Type == string(lokiv1.ConditionReady) {
+		return "", false
+	}
+
+	return c.Reason, true
+}
+
+// PhaseAge returns how long the LokiStack has been in its current phase according to Phase,
+// i.e. the time since the last transition of the highest-priority active condition, e.g. for
+// display in an age column. A transition time ahead of the clock yields a zero age. It returns
+// false if none of the phase conditions is active.
+func PhaseAge(stack *lokiv1.LokiStack) (time.Duration, bool) {
+	c, ok := phaseCondition(stack)
+	if !ok {
+		return 0, false
+	}
+
+	age := now().Sub(c.LastTransitionTime.Time)
+	if age < 0 {
+		return 0, true
+	}
+
+	return age, true
+}
+
+func phaseCondition(stack *lokiv1.LokiStack) (metav1.Condition, bool) {
+	conditions := ConditionsMap(stack)
+	for _, t := range phasePrecedence {
+		if c, ok := conditions[string(t)]; ok && c.Status == metav1.ConditionTrue {
+			return c, true
+		}
+	}
+
+	return metav1.Condition{}, false
+}
@@ -0,0 +1,171 @@
+package status_test
+
+import (
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestPhaseAndNotReadyReason(t *testing.T) {
+	ready := metav1.Condition{
+		Type:   string(lokiv1.ConditionReady),
+		Reason: string(lokiv1.ReasonReadyComponents),
+		Status: metav1.ConditionTrue,
+	}
+	pending := metav1.Condition{
+		Type:   string(lokiv1.ConditionPending),
+		Reason: string(lokiv1.ReasonPendingComponents),
+		Status: metav1.ConditionTrue,
+	}
+	degraded := metav1.Condition{
+		Type:   string(lokiv1.ConditionDegraded),
+		Reason: string(lokiv1.ReasonMissingObjectStorageSecret),
+		Status: metav1.ConditionTrue,
+	}
+	failed := metav1.Condition{
+		Type:   string(lokiv1.ConditionFailed),
+		Reason: string(lokiv1.ReasonFailedComponents),
+		Status: metav1.ConditionFalse,
+	}
+	warning := metav1.Condition{
+		Type:   string(lokiv1.ConditionWarning),
+		Reason: string(lokiv1.ReasonWALDiskPressure),
+		Status: metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name        string
+		conditions  []metav1.Condition
+		wantPhase   lokiv1.LokiStackConditionType
+		wantReason  string
+		wantNoPhase bool
+		wantReady   bool
+	}{
+		{
+			name:        "no conditions",
+			wantNoPhase: true,
+		},
+		{
+			name:       "ready with warning",
+			conditions: []metav1.Condition{warning, ready, failed},
+			wantPhase:  lokiv1.ConditionReady,
+			wantReady:  true,
+		},
+		{
+			name:       "pending",
+			conditions: []metav1.Condition{pending, failed},
+			wantPhase:  lokiv1.ConditionPending,
+			wantReason: string(lokiv1.ReasonPendingComponents),
+		},
+		{
+			name:       "degraded takes precedence over pending",
+			conditions: []metav1.Condition{pending, degraded},
+			wantPhase:  lokiv1.ConditionDegraded,
+			wantReason: string(lokiv1.ReasonMissingObjectStorageSecret),
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			stack := &lokiv1.LokiStack{
+				Status: lokiv1.LokiStackStatus{
+					Conditions: tc.conditions,
+				},
+			}
+
+			phase, ok := status.Phase(stack)
+			require.Equal(t, !tc.wantNoPhase, ok)
+			require.Equal(t, tc.wantPhase, phase)
+
+			reason, notReady := status.NotReadyReason(stack)
+			require.Equal(t, !tc.wantReady, notReady)
+			require.Equal(t, tc.wantReason, reason)
+		})
+	}
+}
+
+func TestPhaseAge(t *testing.T) {
+	current := time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)
+
+	opts := status.DefaultOptions()
+	opts.Clock = clocktesting.NewFakePassiveClock(current)
+	status.Configure(opts)
+	t.Cleanup(func() { status.Configure(status.DefaultOptions()) })
+
+	ready := metav1.Condition{
+		Type:               string(lokiv1.ConditionReady),
+		Reason:             string(lokiv1.ReasonReadyComponents),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(current.Add(-2 * time.Hour)),
+	}
+	degraded := metav1.Condition{
+		Type:               string(lokiv1.ConditionDegraded),
+		Reason:             string(lokiv1.ReasonMissingObjectStorageSecret),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(current.Add(-5 * time.Minute)),
+	}
+	inactive := func(c metav1.Condition) metav1.Condition {
+		c.Status = metav1.ConditionFalse
+		return c
+	}
+
+	table := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantAge    time.Duration
+		wantOK     bool
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name:       "no active phase condition",
+			conditions: []metav1.Condition{inactive(ready)},
+		},
+		{
+			name:       "ready",
+			conditions: []metav1.Condition{ready},
+			wantAge:    2 * time.Hour,
+			wantOK:     true,
+		},
+		{
+			name:       "highest-priority phase",
+			conditions: []metav1.Condition{ready, degraded},
+			wantAge:    5 * time.Minute,
+			wantOK:     true,
+		},
+		{
+			name: "transition ahead of the clock",
+			conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionPending),
+					Reason:             string(lokiv1.ReasonPendingComponents),
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(current.Add(time.Minute)),
+				},
+			},
+			wantOK: true,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			stack := &lokiv1.LokiStack{
+				Status: lokiv1.LokiStackStatus{Conditions: tc.conditions},
+			}
+
+			age, ok := status.PhaseAge(stack)
+			require.Equal(t, tc.wantOK, ok)
+			require.Equal(t, tc.wantAge, age)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package status
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AnnotationPinnedConditions is the LokiStack annotation listing the pinned conditions as
+// comma-separated "Type/Reason" pairs, e.g. "Warning/ManualIntervention". Pinned conditions
+// are exempt from the expiry by ExpireStaleConditions, from the automatic clearing on
+// recovery and from the reset by the MutualExclusionPolicy until unpinned.
+const AnnotationPinnedConditions = "loki.grafana.com/pinnedConditions"
+
+// PinCondition pins the condition of the given type and reason of the LokiStack.
+func PinCondition(ctx context.Context, k k8s.Client, req ctrl.Request, conditionType lokiv1.LokiStackConditionType, reason lokiv1.LokiStackConditionReason) error {
+	return updatePinnedConditions(ctx, k, req, func(pinned map[string]struct{}) {
+		pinned[pinnedKey(string(conditionType), string(reason))] = struct{}{}
+	})
+}
+
+// UnpinCondition unpins the condition of the given type and reason of the LokiStack, thus
+// it expires and clears automatically again.
+func UnpinCondition(ctx context.Context, k k8s.Client, req ctrl.Request, conditionType lokiv1.LokiStackConditionType, reason lokiv1.LokiStackConditionReason) error {
+	return updatePinnedConditions(ctx, k, req, func(pinned map[string]struct{}) {
+		delete(pinned, pinnedKey(string(conditionType), string(reason)))
+	})
+}
+
+func updatePinnedConditions(ctx context.Context, k k8s.Client, req ctrl.Request, mutate func(map[string]struct{})) error {
+	var stack lokiv1.LokiStack
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+	}
+
+	pinned := pinnedConditions(&stack)
+	mutate(pinned)
+
+	keys := make([]string, 0, len(pinned))
+	for key := range pinned {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return updateAnnotation(ctx, k, req, AnnotationPinnedConditions, strings.Join(keys, ","))
+}
+
+// pinnedConditions returns the set of pinned "Type/Reason" pairs of the LokiStack.
+func pinnedConditions(stack *lokiv1.LokiStack) map[string]struct{} {
+	pinned := map[string]struct{}{}
+	for _, key := range strings.Split(stack.Annotations[AnnotationPinnedConditions], ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			pinned[key] = struct{}{}
+		}
+	}
+
+	return pinned
+}
+
+// isPinned returns true if the condition of the given type and reason is pinned.
+func isPinned(stack *lokiv1.LokiStack, conditionType, reason string) bool {
+	_, ok := pinnedConditions(stack)[pinnedKey(conditionType, reason)]
+	return ok
+}
+
+func pinnedKey(conditionType, reason string) string {
+	return conditionType + "/" + reason
+}
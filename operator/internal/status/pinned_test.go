@@ -0,0 +1,185 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPinCondition_SurvivesTTLSweep(t *testing.T) {
+	opts := DefaultOptions()
+	opts.ConditionTTL = time.Hour
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	stale := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionDegraded),
+					Reason:             string(lokiv1.ReasonMissingObjectStorageSecret),
+					Message:            "Missing object storage secret",
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: stale,
+				},
+				{
+					Type:               string(lokiv1.ConditionWarning),
+					Reason:             string(lokiv1.ReasonWALDiskPressure),
+					Message:            "some warning",
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: stale,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	assertions.delete(r.NamespacedName)
+	t.Cleanup(func() { assertions.delete(r.NamespacedName) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Status = obj.(*lokiv1.LokiStack).Status
+		return nil
+	}
+	k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Annotations = obj.GetAnnotations()
+		return nil
+	}
+
+	err := PinCondition(context.Background(), k, r, lokiv1.ConditionDegraded, lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.Equal(t, "Degraded/MissingObjectStorageSecret", s.Annotations[AnnotationPinnedConditions])
+
+	err = ExpireStaleConditions(context.Background(), k, r)
+	require.NoError(t, err)
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionDegraded)].Status)
+	require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionWarning)].Status)
+
+	// Unpinned conditions expire again on the next sweep
+	err = UnpinCondition(context.Background(), k, r, lokiv1.ConditionDegraded, lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.NotContains(t, s.Annotations, AnnotationPinnedConditions)
+
+	err = ExpireStaleConditions(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, metav1.ConditionFalse, ConditionsMap(&s)[string(lokiv1.ConditionDegraded)].Status)
+}
+
+func TestPinCondition_SurvivesAutoClear(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+			Annotations: map[string]string{
+				AnnotationPinnedConditions: "Warning/CacheUnreachable,Warning/ManualIntervention",
+			},
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionWarning),
+					Reason:  string(lokiv1.ReasonCacheUnreachable),
+					Message: "Cache unreachable, queries may be slower: timeout",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	k.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Annotations = obj.GetAnnotations()
+		return nil
+	}
+
+	err := SetCacheCondition(context.Background(), k, r, true, "")
+	require.NoError(t, err)
+	require.Zero(t, sw.UpdateCallCount())
+
+	err = UnpinCondition(context.Background(), k, r, lokiv1.ConditionWarning, lokiv1.ReasonCacheUnreachable)
+	require.NoError(t, err)
+	require.Equal(t, "Warning/ManualIntervention", s.Annotations[AnnotationPinnedConditions])
+
+	err = SetCacheCondition(context.Background(), k, r, true, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestPinCondition_SurvivesMutualExclusion(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+			Annotations: map[string]string{
+				AnnotationPinnedConditions: "Degraded/MissingObjectStorageSecret",
+			},
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionDegraded),
+					Reason:  string(lokiv1.ReasonMissingObjectStorageSecret),
+					Message: "Missing object storage secret",
+					Status:  metav1.ConditionTrue,
+				},
+				{
+					Type:    string(lokiv1.ConditionPending),
+					Reason:  string(lokiv1.ReasonPendingComponents),
+					Message: "Some LokiStack components pending on dependencies",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Status = obj.(*lokiv1.LokiStack).Status
+		return nil
+	}
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionDegraded)].Status)
+	require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionPending)].Status)
+}
@@ -0,0 +1,81 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxListedPods is the maximum number of pod names listed in a condition message.
+const maxListedPods = 5
+
+// withPods appends the sorted names of the affected pods to the message as listed by podList.
+// Without pods the message is returned unchanged.
+func withPods(msg string, pods []string) string {
+	if len(pods) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf("%s: %s", msg, podList(pods))
+}
+
+// podList returns the sorted names of the pods separated by commas. At most maxListedPods
+// names are listed followed by a "+N more" suffix for the remaining pods.
+func podList(pods []string) string {
+	names := append([]string{}, pods...)
+	sort.Strings(names)
+
+	listed := names
+	if len(names) > maxListedPods {
+		listed = names[:maxListedPods]
+	}
+
+	list := strings.Join(listed, ", ")
+	if more := len(names) - len(listed); more > 0 {
+		list = fmt.Sprintf("%s +%d more", list, more)
+	}
+
+	return list
+}
+
+// podsInPhases returns the names of all component pods in any of the given phases.
+func podsInPhases(cs lokiv1.LokiStackComponentStatus, phases ...corev1.PodPhase) []string {
+	components := []lokiv1.PodStatusMap{
+		cs.Compactor,
+		cs.Distributor,
+		cs.Ingester,
+		cs.Querier,
+		cs.QueryFrontend,
+		cs.Gateway,
+		cs.IndexGateway,
+		cs.Ruler,
+	}
+
+	var pods []string
+	for _, psm := range components {
+		for _, phase := range phases {
+			pods = append(pods, psm[phase]...)
+		}
+	}
+
+	return pods
+}
+
+// componentPodStatus returns the pod status maps of the component status keyed by component name.
+func componentPodStatus(cs lokiv1.LokiStackComponentStatus) map[string]lokiv1.PodStatusMap {
+	return map[string]lokiv1.PodStatusMap{
+		manifests.LabelCompactorComponent:     cs.Compactor,
+		manifests.LabelDistributorComponent:   cs.Distributor,
+		manifests.LabelIngesterComponent:      cs.Ingester,
+		manifests.LabelQuerierComponent:       cs.Querier,
+		manifests.LabelQueryFrontendComponent: cs.QueryFrontend,
+		manifests.LabelIndexGatewayComponent:  cs.IndexGateway,
+		manifests.LabelGatewayComponent:       cs.Gateway,
+		manifests.LabelRulerComponent:         cs.Ruler,
+	}
+}
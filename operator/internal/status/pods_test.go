@@ -0,0 +1,89 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWithPods(t *testing.T) {
+	table := []struct {
+		name string
+		pods []string
+		want string
+	}{
+		{
+			name: "no pods",
+			want: "Some LokiStack components failed",
+		},
+		{
+			name: "below cap",
+			pods: []string{"my-stack-querier-b", "my-stack-ingester-0", "my-stack-querier-a"},
+			want: "Some LokiStack components failed: my-stack-ingester-0, my-stack-querier-a, my-stack-querier-b",
+		},
+		{
+			name: "at cap",
+			pods: []string{"pod-5", "pod-4", "pod-3", "pod-2", "pod-1"},
+			want: "Some LokiStack components failed: pod-1, pod-2, pod-3, pod-4, pod-5",
+		},
+		{
+			name: "above cap",
+			pods: []string{"pod-7", "pod-3", "pod-6", "pod-1", "pod-5", "pod-2", "pod-4"},
+			want: "Some LokiStack components failed: pod-1, pod-2, pod-3, pod-4, pod-5 +2 more",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pods := append([]string(nil), tc.pods...)
+			require.Equal(t, tc.want, withPods(messageFailed, pods))
+			// The input is not reordered
+			require.Equal(t, tc.pods, pods)
+		})
+	}
+}
+
+func TestSetDegradedCondition_WithPods(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	pods := []string{"my-stack-ingester-1", "my-stack-ingester-0"}
+	err := SetDegradedCondition(context.Background(), k, r, "Ingesters out of disk", lokiv1.ReasonWALDiskPressure, pods...)
+	require.NoError(t, err)
+
+	// Reordered pods are no transition
+	err = SetDegradedCondition(context.Background(), k, r, "Ingesters out of disk", lokiv1.ReasonWALDiskPressure, "my-stack-ingester-0", "my-stack-ingester-1")
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	degraded := ConditionsMap(&s)[string(lokiv1.ConditionDegraded)]
+	require.Equal(t, "Ingesters out of disk: my-stack-ingester-0, my-stack-ingester-1", degraded.Message)
+}
@@ -0,0 +1,82 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ConditionPolicy defines how setting a condition affects the other conditions of a LokiStack.
+type ConditionPolicy int
+
+const (
+	// MutualExclusionPolicy sets the condition to true and resets all other conditions managed
+	// by the operator to false, i.e. at most one managed condition is active at a time.
+	// Conditions of types not managed by the operator are left untouched. This is the
+	// policy of SetReadyCondition, SetFailedCondition, SetPendingCondition and SetDegradedCondition.
+	MutualExclusionPolicy ConditionPolicy = iota
+	// CoexistPolicy sets the condition to true and leaves all other conditions untouched,
+	// e.g. for warnings reported alongside the Ready condition.
+	CoexistPolicy
+)
+
+// SetCondition updates or appends the condition of the given type to the lokistack status
+// conditions. The other conditions are updated according to the given policy.
+func SetCondition(
+	ctx context.Context,
+	k k8s.Client,
+	req ctrl.Request,
+	conditionType lokiv1.LokiStackConditionType,
+	msg string,
+	reason lokiv1.LokiStackConditionReason,
+	policy ConditionPolicy,
+) error {
+	condition := metav1.Condition{
+		Type:    string(conditionType),
+		Message: msg,
+		Reason:  string(reason),
+	}
+
+	return updateCondition(ctx, k, req, condition, policy)
+}
+
+// apply updates or appends the condition to the conditions and updates the
+// other conditions according to the policy. Active conditions listed in pinned as
+// "Type/Reason" pairs are never reset by the MutualExclusionPolicy.
+func (p ConditionPolicy) apply(conditions []metav1.Condition, condition metav1.Condition, now metav1.Time, pinned map[string]struct{}) []metav1.Condition {
+	condition.LastTransitionTime = now
+
+	index := -1
+	for i := range conditions {
+		// Reset all other managed conditions first
+		if p == MutualExclusionPolicy && isManagedCondition(conditions[i].Type) && !isPinnedActive(pinned, conditions[i]) {
+			conditions[i].Status = metav1.ConditionFalse
+			conditions[i].LastTransitionTime = now
+		}
+
+		// Locate existing condition if any
+		if conditions[i].Type == condition.Type {
+			index = i
+		}
+	}
+
+	if index == -1 {
+		return append(conditions, condition)
+	}
+
+	conditions[index] = condition
+	return conditions
+}
+
+func isPinnedActive(pinned map[string]struct{}, c metav1.Condition) bool {
+	if c.Status != metav1.ConditionTrue {
+		return false
+	}
+
+	_, ok := pinned[pinnedKey(c.Type, c.Reason)]
+	return ok
+}
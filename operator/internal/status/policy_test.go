@@ -0,0 +1,82 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetCondition_Policies(t *testing.T) {
+	table := []struct {
+		name      string
+		policy    ConditionPolicy
+		wantReady metav1.ConditionStatus
+	}{
+		{
+			name:      "mutual exclusion resets other conditions",
+			policy:    MutualExclusionPolicy,
+			wantReady: metav1.ConditionFalse,
+		},
+		{
+			name:      "coexist preserves other conditions",
+			policy:    CoexistPolicy,
+			wantReady: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:    string(lokiv1.ConditionReady),
+							Reason:  string(lokiv1.ReasonReadyComponents),
+							Message: messageReady,
+							Status:  metav1.ConditionTrue,
+						},
+						{
+							Type:   "external.example.com/Custom",
+							Reason: "Custom",
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+				require.Len(t, conditions, 3)
+				require.Equal(t, tc.wantReady, conditions[string(lokiv1.ConditionReady)].Status)
+				require.Equal(t, metav1.ConditionTrue, conditions["external.example.com/Custom"].Status)
+				require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionWarning)].Status)
+				return nil
+			}
+
+			err := SetCondition(context.Background(), k, r, lokiv1.ConditionWarning, "some warning", lokiv1.ReasonWALDiskPressure, tc.policy)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetQueryQueueCondition reports the health of the query scheduler queue. The condition Warning
+// is set alongside the other conditions if the queue is reported saturated or its depth reaches
+// the configured QueryQueueDepthThreshold, as queries time out while the pods look healthy.
+// Otherwise a previously reported saturation is cleared.
+func SetQueryQueueCondition(ctx context.Context, k k8s.Client, req ctrl.Request, saturated bool, depth int) error {
+	threshold := options.QueryQueueDepthThreshold
+	if !saturated && (threshold == 0 || depth < threshold) {
+		return clearWarning(ctx, k, req, lokiv1.ReasonQueryQueueSaturated, "")
+	}
+
+	return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+		Reason:  lokiv1.ReasonQueryQueueSaturated,
+		Message: fmt.Sprintf("Query scheduler queue is saturated with %d queued queries", depth),
+	})
+}
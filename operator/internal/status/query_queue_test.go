@@ -0,0 +1,130 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetQueryQueueCondition(t *testing.T) {
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	queueWarning := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonQueryQueueSaturated),
+		Message: "Query scheduler queue is saturated with 150 queued queries",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name       string
+		threshold  int
+		saturated  bool
+		depth      int
+		conditions []metav1.Condition
+		wantUpdate bool
+		wantStatus metav1.ConditionStatus
+		wantMsg    string
+	}{
+		{
+			name:       "normal queue depth",
+			threshold:  100,
+			depth:      99,
+			conditions: []metav1.Condition{ready},
+		},
+		{
+			name:       "normal queue depth clears warning",
+			threshold:  100,
+			depth:      10,
+			conditions: []metav1.Condition{ready, queueWarning},
+			wantUpdate: true,
+			wantStatus: metav1.ConditionFalse,
+			wantMsg:    queueWarning.Message,
+		},
+		{
+			name:       "at depth threshold",
+			threshold:  100,
+			depth:      100,
+			conditions: []metav1.Condition{ready},
+			wantUpdate: true,
+			wantStatus: metav1.ConditionTrue,
+			wantMsg:    "Query scheduler queue is saturated with 100 queued queries",
+		},
+		{
+			name:       "reported saturated below depth threshold",
+			threshold:  100,
+			saturated:  true,
+			depth:      20,
+			conditions: []metav1.Condition{ready},
+			wantUpdate: true,
+			wantStatus: metav1.ConditionTrue,
+			wantMsg:    "Query scheduler queue is saturated with 20 queued queries",
+		},
+		{
+			name:       "depth check disabled",
+			depth:      1000,
+			conditions: []metav1.Condition{ready},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() { Configure(DefaultOptions()) })
+
+			opts := DefaultOptions()
+			opts.QueryQueueDepthThreshold = tc.threshold
+			Configure(opts)
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+				warning := conditions[string(lokiv1.ConditionWarning)]
+				require.Equal(t, tc.wantStatus, warning.Status)
+				require.Equal(t, string(lokiv1.ReasonQueryQueueSaturated), warning.Reason)
+				require.Equal(t, tc.wantMsg, warning.Message)
+				require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+				return nil
+			}
+
+			err := SetQueryQueueCondition(context.Background(), k, r, tc.saturated, tc.depth)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const messageReadOnly = "LokiStack is in read-only mode, writes are rejected"
+
+// SetReadOnlyCondition sets the condition ReadOnly alongside the other conditions if the
+// LokiStack is intentionally put in read-only mode and clears it otherwise. While read-only,
+// failed or pending write path components do not make the LokiStack Failed or Pending on Refresh.
+func SetReadOnlyCondition(ctx context.Context, k k8s.Client, req ctrl.Request, readOnly bool) error {
+	if !readOnly {
+		return clearCondition(ctx, k, req, lokiv1.ConditionReadOnly, lokiv1.ReasonReadOnlyMode)
+	}
+
+	return updateCondition(ctx, k, req, metav1.Condition{
+		Type:    string(lokiv1.ConditionReadOnly),
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionReadOnly, LocalizableMessage{Key: MessageKeyReadOnly}),
+		Reason:  string(lokiv1.ReasonReadOnlyMode),
+	}, CoexistPolicy)
+}
+
+// isReadOnly returns true if the LokiStack has the condition ReadOnly set to true.
+func isReadOnly(stack *lokiv1.LokiStack) bool {
+	for _, c := range stack.Status.Conditions {
+		if c.Type == string(lokiv1.ConditionReadOnly) && c.Status == metav1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
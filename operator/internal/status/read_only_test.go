@@ -0,0 +1,184 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetReadOnlyCondition(t *testing.T) {
+	readOnly := metav1.Condition{
+		Type:    string(lokiv1.ConditionReadOnly),
+		Reason:  string(lokiv1.ReasonReadOnlyMode),
+		Message: messageReadOnly,
+		Status:  metav1.ConditionTrue,
+	}
+
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name         string
+		readOnly     bool
+		conditions   []metav1.Condition
+		wantUpdate   bool
+		wantReadOnly metav1.ConditionStatus
+	}{
+		{
+			name:         "enable read-only mode",
+			readOnly:     true,
+			conditions:   []metav1.Condition{ready},
+			wantUpdate:   true,
+			wantReadOnly: metav1.ConditionTrue,
+		},
+		{
+			name:       "already read-only",
+			readOnly:   true,
+			conditions: []metav1.Condition{ready, readOnly},
+		},
+		{
+			name:         "disable read-only mode",
+			conditions:   []metav1.Condition{ready, readOnly},
+			wantUpdate:   true,
+			wantReadOnly: metav1.ConditionFalse,
+		},
+		{
+			name:       "never read-only",
+			conditions: []metav1.Condition{ready},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+				require.Equal(t, tc.wantReadOnly, conditions[string(lokiv1.ConditionReadOnly)].Status)
+				require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+				return nil
+			}
+
+			err := SetReadOnlyCondition(context.Background(), k, r, tc.readOnly)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestRefresh_WhenReadOnly_IgnoreWritePathFailures(t *testing.T) {
+	table := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantType   lokiv1.LokiStackConditionType
+	}{
+		{
+			name:     "not read-only",
+			wantType: lokiv1.ConditionFailed,
+		},
+		{
+			name: "read-only",
+			conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReadOnly),
+					Reason:  string(lokiv1.ReasonReadOnlyMode),
+					Message: messageReadOnly,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+			wantType: lokiv1.ConditionReady,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			k.ListStub = func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+
+				phase := corev1.PodRunning
+				distributor := labels.Set(manifests.ComponentLabels(manifests.LabelDistributorComponent, s.Name))
+				if lo.LabelSelector.Matches(distributor) {
+					phase = corev1.PodFailed
+				}
+
+				k.SetClientObjectList(l, &corev1.PodList{
+					Items: []corev1.Pod{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+							Status:     corev1.PodStatus{Phase: phase},
+						},
+					},
+				})
+				return nil
+			}
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := Refresh(context.Background(), k, r)
+			require.NoError(t, err)
+
+			conditions := ConditionsMap(&s)
+			require.Equal(t, metav1.ConditionTrue, conditions[string(tc.wantType)].Status)
+			require.NotEqual(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionReadOnly)].Status)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package status
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ReconcileConditions brings the lokistack status conditions in line with the desired set of
+// conditions in a single status write. Desired conditions without a status are considered true.
+// Only the delta is applied:
+//
+// - Desired conditions missing from or differing in status, reason or message from the status are added or updated.
+// - Active managed conditions missing from the desired set are reset to false.
+// - Conditions of types not managed by the operator and absent from the desired set are left untouched.
+//
+// With MutualExclusionPolicy the desired set must not contain more than one true state condition,
+// i.e. Warning conditions may still coexist. Reconciling an already matching status is a no-op.
+func ReconcileConditions(
+	ctx context.Context,
+	k k8s.Client,
+	req ctrl.Request,
+	desired []metav1.Condition,
+	policy ConditionPolicy,
+) error {
+	wanted := make(map[string]metav1.Condition, len(desired))
+	var active []string
+	for _, c := range desired {
+		if c.Status == "" {
+			c.Status = metav1.ConditionTrue
+		}
+		if _, ok := wanted[c.Type]; ok {
+			return kverrors.New("duplicate desired condition type", "name", req.NamespacedName, "type", c.Type)
+		}
+		wanted[c.Type] = c
+
+		if c.Status == metav1.ConditionTrue && isStateCondition(c.Type) {
+			active = append(active, c.Type)
+		}
+	}
+
+	if policy == MutualExclusionPolicy && len(active) > 1 {
+		return kverrors.New("desired conditions violate mutual exclusion", "name", req.NamespacedName, "types", active)
+	}
+
+	for _, c := range wanted {
+		if c.Status == metav1.ConditionTrue {
+			recordAssertion(req.NamespacedName, c.Type, now())
+		}
+	}
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		return reconcileConditions(stack, desired, wanted, metav1.NewTime(now()))
+	})
+}
+
+// reconcileConditions applies the delta between the stack conditions and the wanted
+// conditions and reports whether any condition changed. New conditions are appended
+// in the order of desired.
+func reconcileConditions(stack *lokiv1.LokiStack, desired []metav1.Condition, wanted map[string]metav1.Condition, now metav1.Time) bool {
+	var (
+		changed bool
+		present = map[string]struct{}{}
+	)
+
+	for i, c := range stack.Status.Conditions {
+		present[c.Type] = struct{}{}
+
+		w, ok := wanted[c.Type]
+		switch {
+		case ok:
+			if c.Status == w.Status && c.Reason == w.Reason && c.Message == w.Message {
+				continue
+			}
+			w.LastTransitionTime = now
+			stack.Status.Conditions[i] = w
+			changed = true
+		case isManagedCondition(c.Type) && c.Status == metav1.ConditionTrue:
+			stack.Status.Conditions[i].Status = metav1.ConditionFalse
+			stack.Status.Conditions[i].LastTransitionTime = now
+			changed = true
+		}
+	}
+
+	for _, c := range desired {
+		if _, ok := present[c.Type]; ok {
+			continue
+		}
+		w := wanted[c.Type]
+		w.LastTransitionTime = now
+		stack.Status.Conditions = append(stack.Status.Conditions, w)
+		present[c.Type] = struct{}{}
+		changed = true
+	}
+
+	return changed
+}
@@ -0,0 +1,158 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileConditions_Deltas(t *testing.T) {
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+	}
+	warning := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonWALDiskPressure),
+		Message: "some warning",
+	}
+	degraded := metav1.Condition{
+		Type:    string(lokiv1.ConditionDegraded),
+		Reason:  string(lokiv1.ReasonMissingObjectStorageSecret),
+		Message: "some degraded",
+	}
+	custom := metav1.Condition{
+		Type:   "external.example.com/Custom",
+		Reason: "Custom",
+		Status: metav1.ConditionTrue,
+	}
+
+	withStatus := func(c metav1.Condition, s metav1.ConditionStatus) metav1.Condition {
+		c.Status = s
+		return c
+	}
+
+	table := []struct {
+		name    string
+		current []metav1.Condition
+		desired []metav1.Condition
+		want    map[string]metav1.Condition
+	}{
+		{
+			name:    "add",
+			current: []metav1.Condition{withStatus(ready, metav1.ConditionTrue), custom},
+			desired: []metav1.Condition{ready, warning},
+			want: map[string]metav1.Condition{
+				ready.Type:   withStatus(ready, metav1.ConditionTrue),
+				warning.Type: withStatus(warning, metav1.ConditionTrue),
+				custom.Type:  custom,
+			},
+		},
+		{
+			name:    "remove",
+			current: []metav1.Condition{withStatus(ready, metav1.ConditionTrue), withStatus(warning, metav1.ConditionTrue), custom},
+			desired: []metav1.Condition{ready},
+			want: map[string]metav1.Condition{
+				ready.Type:   withStatus(ready, metav1.ConditionTrue),
+				warning.Type: withStatus(warning, metav1.ConditionFalse),
+				custom.Type:  custom,
+			},
+		},
+		{
+			name:    "update",
+			current: []metav1.Condition{withStatus(ready, metav1.ConditionTrue), withStatus(degraded, metav1.ConditionFalse), custom},
+			desired: []metav1.Condition{withStatus(ready, metav1.ConditionFalse), degraded},
+			want: map[string]metav1.Condition{
+				ready.Type:    withStatus(ready, metav1.ConditionFalse),
+				degraded.Type: withStatus(degraded, metav1.ConditionTrue),
+				custom.Type:   custom,
+			},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: tc.current,
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := ReconcileConditions(context.Background(), k, r, tc.desired, MutualExclusionPolicy)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+
+			conditions := ConditionsMap(&s)
+			require.Len(t, conditions, len(tc.want))
+			for typ, want := range tc.want {
+				got := conditions[typ]
+				require.Equal(t, want.Status, got.Status, typ)
+				require.Equal(t, want.Reason, got.Reason, typ)
+				require.Equal(t, want.Message, got.Message, typ)
+			}
+
+			// Reconciling the same desired set again is a no-op
+			err = ReconcileConditions(context.Background(), k, r, tc.desired, MutualExclusionPolicy)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+		})
+	}
+}
+
+func TestReconcileConditions_MutualExclusion(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	desired := []metav1.Condition{
+		{Type: string(lokiv1.ConditionReady), Reason: string(lokiv1.ReasonReadyComponents)},
+		{Type: string(lokiv1.ConditionDegraded), Reason: string(lokiv1.ReasonMissingObjectStorageSecret)},
+	}
+
+	k, sw := setupFakes(&s)
+
+	err := ReconcileConditions(context.Background(), k, r, desired, MutualExclusionPolicy)
+	require.Error(t, err)
+	require.Zero(t, sw.UpdateCallCount())
+
+	err = ReconcileConditions(context.Background(), k, r, desired, CoexistPolicy)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
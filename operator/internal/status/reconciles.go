@@ -0,0 +1,41 @@
+package status
+
+import (
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcilesSinceReady counts the reconciliations per LokiStack observed while not ready.
+var reconcilesSinceReady = newStackStore[int]()
+
+// ObserveReconcile records a reconciliation of the LokiStack. The count of reconciliations
+// since the LokiStack was last ready is incremented if it is not ready according to
+// NotReadyReason and reset otherwise. It returns the updated count. The count is kept in
+// memory, i.e. an operator restart starts over.
+func ObserveReconcile(stack *lokiv1.LokiStack) int {
+	key := client.ObjectKeyFromObject(stack)
+	if _, notReady := NotReadyReason(stack); !notReady {
+		reconcilesSinceReady.delete(key)
+		return 0
+	}
+
+	var count int
+	reconcilesSinceReady.update(key, func(c *int) {
+		*c++
+		count = *c
+	})
+
+	return count
+}
+
+// ReconcilesSinceReady returns the number of reconciliations observed since the LokiStack
+// was last ready. A high count suggests the reconciliation of the LokiStack is stuck.
+func ReconcilesSinceReady(stack *lokiv1.LokiStack) int {
+	var count int
+	reconcilesSinceReady.read(client.ObjectKeyFromObject(stack), func(c *int) {
+		count = *c
+	})
+
+	return count
+}
@@ -0,0 +1,53 @@
+package status
+
+import (
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestObserveReconcile_IncrementAndReset(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(lokiv1.ConditionPending),
+					Reason: string(lokiv1.ReasonPendingComponents),
+					Status: metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+	key := client.ObjectKeyFromObject(&s)
+	reconcilesSinceReady.delete(key)
+	t.Cleanup(func() { reconcilesSinceReady.delete(key) })
+
+	require.Zero(t, ReconcilesSinceReady(&s))
+
+	for i := 1; i <= 3; i++ {
+		require.Equal(t, i, ObserveReconcile(&s))
+		require.Equal(t, i, ReconcilesSinceReady(&s))
+	}
+
+	s.Status.Conditions = append(s.Status.Conditions, metav1.Condition{
+		Type:   string(lokiv1.ConditionReady),
+		Reason: string(lokiv1.ReasonReadyComponents),
+		Status: metav1.ConditionTrue,
+	})
+	s.Status.Conditions[0].Status = metav1.ConditionFalse
+
+	require.Zero(t, ObserveReconcile(&s))
+	require.Zero(t, ReconcilesSinceReady(&s))
+
+	s.Status.Conditions[1].Status = metav1.ConditionFalse
+	require.Equal(t, 1, ObserveReconcile(&s))
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// setReadyOrRecoveringCondition sets the condition Recovering instead of Ready if the LokiStack
+// was Degraded or Failed before. Once the condition Recovering is active for the configured
+// RecoveryWindow the condition Ready is set.
+func setReadyOrRecoveringCondition(ctx context.Context, k k8s.Client, req ctrl.Request, stack *lokiv1.LokiStack) error {
+	if options.RecoveryWindow == 0 {
+		return SetReadyCondition(ctx, k, req)
+	}
+
+	conditions := ConditionsMap(stack)
+	if recovering, ok := conditions[string(lokiv1.ConditionRecovering)]; ok && recovering.Status == metav1.ConditionTrue {
+		if recoveryRemaining(recovering) > 0 {
+			// not stable for long enough
+			return nil
+		}
+		return SetReadyCondition(ctx, k, req)
+	}
+
+	if conditions[string(lokiv1.ConditionDegraded)].Status != metav1.ConditionTrue &&
+		conditions[string(lokiv1.ConditionFailed)].Status != metav1.ConditionTrue {
+		return SetReadyCondition(ctx, k, req)
+	}
+
+	return setRecoveredCondition(ctx, k, req, metav1.Condition{
+		Type:    string(lokiv1.ConditionRecovering),
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionRecovering, LocalizableMessage{Key: MessageKeyRecovering}),
+		Reason:  string(lokiv1.ReasonRecoveringComponents),
+	})
+}
+
+// RequeueWhileRecovering returns a result requeueing the request once the recovery window of
+// the active condition Recovering elapsed, so that the LokiStack becomes Ready without waiting
+// for another event. Without an active condition Recovering it returns an empty result.
+func RequeueWhileRecovering(ctx context.Context, k k8s.Client, req ctrl.Request) (ctrl.Result, error) {
+	if options.RecoveryWindow == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	var stack lokiv1.LokiStack
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+	}
+
+	recovering, ok := ConditionsMap(&stack)[string(lokiv1.ConditionRecovering)]
+	if !ok || recovering.Status != metav1.ConditionTrue {
+		return ctrl.Result{}, nil
+	}
+
+	remaining := recoveryRemaining(recovering)
+	if remaining <= 0 {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: remaining}, nil
+}
+
+func recoveryRemaining(recovering metav1.Condition) time.Duration {
+	return options.RecoveryWindow - now().Sub(recovering.LastTransitionTime.Time)
+}
@@ -0,0 +1,166 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRefresh_RecoveringTransitions(t *testing.T) {
+	table := []struct {
+		name     string
+		previous lokiv1.LokiStackConditionType
+		window   time.Duration
+		want     lokiv1.LokiStackConditionType
+	}{
+		{
+			name:     "from degraded",
+			previous: lokiv1.ConditionDegraded,
+			window:   time.Minute,
+			want:     lokiv1.ConditionRecovering,
+		},
+		{
+			name:     "from failed",
+			previous: lokiv1.ConditionFailed,
+			window:   time.Minute,
+			want:     lokiv1.ConditionRecovering,
+		},
+		{
+			name:     "from pending",
+			previous: lokiv1.ConditionPending,
+			window:   time.Minute,
+			want:     lokiv1.ConditionReady,
+		},
+		{
+			name:     "from degraded without recovery window",
+			previous: lokiv1.ConditionDegraded,
+			want:     lokiv1.ConditionReady,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.RecoveryWindow = tc.window
+			Configure(opts)
+			t.Cleanup(func() { Configure(DefaultOptions()) })
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(tc.previous),
+							Reason: "Previous",
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			require.NoError(t, Refresh(context.Background(), k, r))
+
+			phase, ok := Phase(&s)
+			require.True(t, ok)
+			require.Equal(t, tc.want, phase)
+			require.Equal(t, metav1.ConditionFalse, ConditionsMap(&s)[string(tc.previous)].Status)
+		})
+	}
+}
+
+func TestRefresh_WhenRecoveryWindowElapsed_SetReady(t *testing.T) {
+	fakeClock := clocktesting.NewFakePassiveClock(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+
+	opts := DefaultOptions()
+	opts.Clock = fakeClock
+	opts.RecoveryWindow = time.Minute
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionDegraded),
+					Reason:  string(lokiv1.ReasonMissingObjectStorageSecret),
+					Message: "Missing object storage secret",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	// Degraded -> Recovering
+	require.NoError(t, Refresh(context.Background(), k, r))
+	phase, _ := Phase(&s)
+	require.Equal(t, lokiv1.ConditionRecovering, phase)
+
+	res, err := RequeueWhileRecovering(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, res)
+
+	// Still within the recovery window
+	fakeClock.SetTime(fakeClock.Now().Add(40 * time.Second))
+	require.NoError(t, Refresh(context.Background(), k, r))
+	phase, _ = Phase(&s)
+	require.Equal(t, lokiv1.ConditionRecovering, phase)
+
+	res, err = RequeueWhileRecovering(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{RequeueAfter: 20 * time.Second}, res)
+
+	// Recovering -> Ready
+	fakeClock.SetTime(fakeClock.Now().Add(20 * time.Second))
+	require.NoError(t, Refresh(context.Background(), k, r))
+	phase, _ = Phase(&s)
+	require.Equal(t, lokiv1.ConditionReady, phase)
+	require.Equal(t, metav1.ConditionFalse, ConditionsMap(&s)[string(lokiv1.ConditionRecovering)].Status)
+
+	res, err = RequeueWhileRecovering(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{}, res)
+}
@@ -0,0 +1,67 @@
+package status
+
+import (
+	"context"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ReplaceConditions overwrites all lokistack status conditions, including those of types not
+// managed by the operator, with the given conditions in a single status write retried on
+// conflicts, e.g. to restore a previously saved status. Conditions without a status are
+// considered true. Conditions matching a current condition in type, status, reason and message
+// keep its LastTransitionTime. All others keep their given LastTransitionTime or, if unset,
+// transition now. Replacing with the current conditions is a no-op.
+func ReplaceConditions(ctx context.Context, k k8s.Client, req ctrl.Request, conditions []metav1.Condition) error {
+	seen := make(map[string]struct{}, len(conditions))
+	for _, c := range conditions {
+		if _, ok := seen[c.Type]; ok {
+			return kverrors.New("duplicate condition type", "name", req.NamespacedName, "type", c.Type)
+		}
+		seen[c.Type] = struct{}{}
+	}
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		replaced := replaceConditions(stack.Status.Conditions, conditions, metav1.NewTime(now()))
+		if equality.Semantic.DeepEqual(stack.Status.Conditions, replaced) {
+			return false
+		}
+
+		stack.Status.Conditions = replaced
+		return true
+	})
+}
+
+// replaceConditions returns a copy of the conditions with the LastTransitionTime of the
+// unchanged current conditions preserved.
+func replaceConditions(current, conditions []metav1.Condition, now metav1.Time) []metav1.Condition {
+	previous := make(map[string]metav1.Condition, len(current))
+	for _, c := range current {
+		previous[c.Type] = c
+	}
+
+	replaced := make([]metav1.Condition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Status == "" {
+			c.Status = metav1.ConditionTrue
+		}
+
+		p, ok := previous[c.Type]
+		switch {
+		case ok && p.Status == c.Status && p.Reason == c.Reason && p.Message == c.Message:
+			c.LastTransitionTime = p.LastTransitionTime
+		case c.LastTransitionTime.IsZero():
+			c.LastTransitionTime = now
+		}
+
+		replaced = append(replaced, c)
+	}
+
+	return replaced
+}
@@ -0,0 +1,175 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReplaceConditions(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	then := metav1.NewTime(time.Date(2022, 10, 11, 11, 0, 0, 0, time.UTC))
+	restored := metav1.NewTime(time.Date(2022, 10, 10, 8, 0, 0, 0, time.UTC))
+	current := metav1.NewTime(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+
+	opts := DefaultOptions()
+	opts.Clock = clocktesting.NewFakePassiveClock(current.Time)
+	Configure(opts)
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionReady),
+					Reason:             string(lokiv1.ReasonReadyComponents),
+					Message:            messageReady,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: then,
+				},
+				{
+					Type:               string(lokiv1.ConditionWarning),
+					Reason:             string(lokiv1.ReasonWALDiskPressure),
+					Message:            "some warning",
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: then,
+				},
+				{
+					Type:               "external.example.com/Custom",
+					Reason:             "Custom",
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: then,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	conditions := []metav1.Condition{
+		// unchanged
+		{
+			Type:    string(lokiv1.ConditionReady),
+			Reason:  string(lokiv1.ReasonReadyComponents),
+			Message: messageReady,
+		},
+		// changed
+		{
+			Type:    string(lokiv1.ConditionWarning),
+			Reason:  string(lokiv1.ReasonWALDiskPressure),
+			Message: "other warning",
+			Status:  metav1.ConditionTrue,
+		},
+		// restored with its transition time
+		{
+			Type:               string(lokiv1.ConditionDegraded),
+			Reason:             string(lokiv1.ReasonMissingObjectStorageSecret),
+			Message:            "Missing object storage secret",
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: restored,
+		},
+	}
+
+	err := ReplaceConditions(context.Background(), k, r, conditions)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	require.Len(t, s.Status.Conditions, 3)
+	got := ConditionsMap(&s)
+	require.NotContains(t, got, "external.example.com/Custom")
+	require.Equal(t, metav1.ConditionTrue, got[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, then, got[string(lokiv1.ConditionReady)].LastTransitionTime)
+	require.Equal(t, "other warning", got[string(lokiv1.ConditionWarning)].Message)
+	require.Equal(t, current, got[string(lokiv1.ConditionWarning)].LastTransitionTime)
+	require.Equal(t, metav1.ConditionFalse, got[string(lokiv1.ConditionDegraded)].Status)
+	require.Equal(t, restored, got[string(lokiv1.ConditionDegraded)].LastTransitionTime)
+
+	// Replacing with the same conditions again is a no-op
+	err = ReplaceConditions(context.Background(), k, r, conditions)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
+
+func TestReplaceConditions_RetriesOnConflict(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		if sw.UpdateCallCount() == 1 {
+			return apierrors.NewConflict(schema.GroupResource{}, "my-stack", nil)
+		}
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := ReplaceConditions(context.Background(), k, r, []metav1.Condition{
+		{Type: string(lokiv1.ConditionReady), Reason: string(lokiv1.ReasonReadyComponents), Message: messageReady},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, sw.UpdateCallCount())
+	require.Len(t, s.Status.Conditions, 1)
+}
+
+func TestReplaceConditions_WhenDuplicateTypes_ReturnError(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+
+	err := ReplaceConditions(context.Background(), k, r, []metav1.Condition{
+		{Type: string(lokiv1.ConditionReady), Reason: string(lokiv1.ReasonReadyComponents)},
+		{Type: string(lokiv1.ConditionReady), Reason: string(lokiv1.ReasonReadyComponents)},
+	})
+	require.Error(t, err)
+	require.Zero(t, sw.UpdateCallCount())
+}
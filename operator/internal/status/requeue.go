@@ -0,0 +1,61 @@
+package status
+
+import (
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+// requeueIntervals maps the phase condition types to the interval after which a LokiStack in
+// this phase is requeued. Zero or missing types are not requeued, i.e. they are expected to
+// be resolved by a change of a watched resource, e.g. fixing an invalid LokiStack spec.
+var requeueIntervals = map[lokiv1.LokiStackConditionType]time.Duration{
+	lokiv1.ConditionFailed:  time.Minute,
+	lokiv1.ConditionPending: 10 * time.Second,
+}
+
+// reasonRequeueIntervals overrides requeueIntervals for the reasons whose cause is not
+// reflected by a watched resource, e.g. an unreachable object storage.
+var reasonRequeueIntervals = map[lokiv1.LokiStackConditionReason]time.Duration{
+	lokiv1.ReasonPendingSchemaChangeApproval:         0,
+	lokiv1.ReasonReconcileFailed:                     30 * time.Second,
+	lokiv1.ReasonMissingResource:                     30 * time.Second,
+	lokiv1.ReasonForbiddenAccess:                     time.Minute,
+	lokiv1.ReasonObjectStorageCanaryFailed:           time.Minute,
+	lokiv1.ReasonCacheUnreachable:                    time.Minute,
+	lokiv1.ReasonUnschedulableAffinity:               time.Minute,
+	lokiv1.ReasonRolloutBlockedByPodDisruptionBudget: time.Minute,
+	lokiv1.ReasonClockSkew:                           5 * time.Minute,
+	lokiv1.ReasonInsufficientClusterCapacity:         5 * time.Minute,
+	lokiv1.ReasonInsufficientVolumeSize:              5 * time.Minute,
+}
+
+// ShouldRequeue recommends whether and after which duration the LokiStack should be requeued
+// based on its highest-priority active condition according to the precedence of Phase. The
+// reason of the condition takes precedence over its type, see reasonRequeueIntervals. A
+// LokiStack Recovering is requeued once its recovery window elapsed. A LokiStack without any
+// active phase condition is requeued like a Pending one. It returns (false, 0) when Ready.
+func ShouldRequeue(stack *lokiv1.LokiStack) (bool, time.Duration) {
+	c, ok := phaseCondition(stack)
+	if !ok {
+		return true, requeueIntervals[lokiv1.ConditionPending]
+	}
+
+	switch lokiv1.LokiStackConditionType(c.Type) {
+	case lokiv1.ConditionReady:
+		return false, 0
+	case lokiv1.ConditionRecovering:
+		remaining := recoveryRemaining(c)
+		if remaining < 0 {
+			remaining = 0
+		}
+		return true, remaining
+	}
+
+	after, ok := reasonRequeueIntervals[lokiv1.LokiStackConditionReason(c.Reason)]
+	if !ok {
+		after = requeueIntervals[lokiv1.LokiStackConditionType(c.Type)]
+	}
+
+	return after > 0, after
+}
@@ -0,0 +1,112 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestShouldRequeue(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	clk := clocktesting.NewFakePassiveClock(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))
+	opts := DefaultOptions()
+	opts.Clock = clk
+	opts.RecoveryWindow = time.Minute
+	Configure(opts)
+
+	active := func(conditionType lokiv1.LokiStackConditionType, reason lokiv1.LokiStackConditionReason) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(conditionType),
+			Reason:             string(reason),
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(clk.Now().Add(-20 * time.Second)),
+		}
+	}
+
+	table := []struct {
+		name        string
+		conditions  []metav1.Condition
+		wantRequeue bool
+		wantAfter   time.Duration
+	}{
+		{
+			name:       "ready",
+			conditions: []metav1.Condition{active(lokiv1.ConditionReady, lokiv1.ReasonReadyComponents)},
+		},
+		{
+			name: "ready with warning",
+			conditions: []metav1.Condition{
+				active(lokiv1.ConditionReady, lokiv1.ReasonReadyComponents),
+				active(lokiv1.ConditionWarning, lokiv1.ReasonWALDiskPressure),
+			},
+		},
+		{
+			name:        "no conditions",
+			wantRequeue: true,
+			wantAfter:   10 * time.Second,
+		},
+		{
+			name:        "pending",
+			conditions:  []metav1.Condition{active(lokiv1.ConditionPending, lokiv1.ReasonPendingComponents)},
+			wantRequeue: true,
+			wantAfter:   10 * time.Second,
+		},
+		{
+			name:       "pending on schema change approval",
+			conditions: []metav1.Condition{active(lokiv1.ConditionPending, lokiv1.ReasonPendingSchemaChangeApproval)},
+		},
+		{
+			name:        "failed",
+			conditions:  []metav1.Condition{active(lokiv1.ConditionFailed, lokiv1.ReasonFailedComponents)},
+			wantRequeue: true,
+			wantAfter:   time.Minute,
+		},
+		{
+			name:       "degraded by configuration",
+			conditions: []metav1.Condition{active(lokiv1.ConditionDegraded, lokiv1.ReasonMissingObjectStorageSecret)},
+		},
+		{
+			name:        "degraded by external state",
+			conditions:  []metav1.Condition{active(lokiv1.ConditionDegraded, lokiv1.ReasonObjectStorageCanaryFailed)},
+			wantRequeue: true,
+			wantAfter:   time.Minute,
+		},
+		{
+			name:        "recovering",
+			conditions:  []metav1.Condition{active(lokiv1.ConditionRecovering, lokiv1.ReasonRecoveringComponents)},
+			wantRequeue: true,
+			wantAfter:   40 * time.Second,
+		},
+		{
+			name: "failed takes precedence over degraded",
+			conditions: []metav1.Condition{
+				active(lokiv1.ConditionDegraded, lokiv1.ReasonMissingObjectStorageSecret),
+				active(lokiv1.ConditionFailed, lokiv1.ReasonFailedComponents),
+			},
+			wantRequeue: true,
+			wantAfter:   time.Minute,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			stack := &lokiv1.LokiStack{
+				Status: lokiv1.LokiStackStatus{
+					Conditions: tc.conditions,
+				},
+			}
+
+			requeue, after := ShouldRequeue(stack)
+			require.Equal(t, tc.wantRequeue, requeue)
+			require.Equal(t, tc.wantAfter, after)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package status
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// throttlingBackoff defines the backoff for retrying requests throttled by the apiserver.
+var throttlingBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// retryOnConflictOrThrottling runs fn and retries it on conflicts using retry.DefaultRetry.
+// Requests rejected with 429 Too Many Requests are retried separately with throttlingBackoff,
+// thus throttling does not consume the conflict retries and vice versa.
+func retryOnConflictOrThrottling(fn func() error) error {
+	return retry.OnError(throttlingBackoff, apierrors.IsTooManyRequests, func() error {
+		return retry.RetryOnConflict(retry.DefaultRetry, fn)
+	})
+}
@@ -0,0 +1,82 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func setupFastThrottlingBackoff(t *testing.T) {
+	previous := throttlingBackoff
+	throttlingBackoff = wait.Backoff{Steps: 5, Duration: time.Millisecond, Factor: 1.0}
+	t.Cleanup(func() { throttlingBackoff = previous })
+}
+
+func TestSetReadyCondition_WhenThrottled_RetryWithBackoff(t *testing.T) {
+	setupFastThrottlingBackoff(t)
+
+	table := []struct {
+		name        string
+		throttled   int
+		wantErr     bool
+		wantUpdates int
+	}{
+		{
+			name:        "repeated 429s followed by success",
+			throttled:   3,
+			wantUpdates: 4,
+		},
+		{
+			name:        "429s exceeding the backoff steps",
+			throttled:   10,
+			wantErr:     true,
+			wantUpdates: 5,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+				if sw.UpdateCallCount() <= tc.throttled {
+					return apierrors.NewTooManyRequests("slow down", 1)
+				}
+				return nil
+			}
+
+			err := SetReadyCondition(context.Background(), k, r)
+			if tc.wantErr {
+				require.True(t, apierrors.IsTooManyRequests(err))
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.wantUpdates, sw.UpdateCallCount())
+		})
+	}
+}
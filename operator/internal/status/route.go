@@ -0,0 +1,26 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetRouteCondition reports the admission of the external gateway route or ingress for the
+// given host. If it is not admitted the condition Warning is set alongside the other conditions,
+// because the LokiStack is unreachable from outside the cluster even with ready gateway pods.
+// Once admitted a previously reported warning is cleared.
+func SetRouteCondition(ctx context.Context, k k8s.Client, req ctrl.Request, admitted bool, host string) error {
+	if admitted {
+		return clearWarning(ctx, k, req, lokiv1.ReasonGatewayRouteNotAdmitted, "")
+	}
+
+	return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+		Reason:  lokiv1.ReasonGatewayRouteNotAdmitted,
+		Message: fmt.Sprintf("Gateway route for host %s is not admitted", host),
+	})
+}
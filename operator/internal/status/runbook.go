@@ -0,0 +1,20 @@
+package status
+
+import (
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runbookURL returns the runbook URL configured by Options.RunbookURLs for the reason of the
+// active Degraded condition of the LokiStack or an empty string. It is stamped into
+// Status.RunbookURL, i.e. cleared as soon as the LokiStack is not degraded anymore.
+func runbookURL(stack *lokiv1.LokiStack) string {
+	for _, c := range stack.Status.Conditions {
+		if c.Type == string(lokiv1.ConditionDegraded) && c.Status == metav1.ConditionTrue {
+			return options.RunbookURLs[lokiv1.LokiStackConditionReason(c.Reason)]
+		}
+	}
+
+	return ""
+}
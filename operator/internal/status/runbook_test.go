@@ -0,0 +1,87 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpdateCondition_StampsRunbookURL(t *testing.T) {
+	opts := DefaultOptions()
+	opts.RunbookURLs = map[lokiv1.LokiStackConditionReason]string{
+		lokiv1.ReasonMissingObjectStorageSecret: "https://runbooks.example.com/loki#missing-storage-secret",
+	}
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Status = obj.(*lokiv1.LokiStack).Status
+		return nil
+	}
+
+	err := SetDegradedCondition(context.Background(), k, r, "Missing object storage secret", lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.Equal(t, "https://runbooks.example.com/loki#missing-storage-secret", s.Status.RunbookURL)
+
+	// Reasons without a runbook clear the URL
+	err = SetDegradedCondition(context.Background(), k, r, "Invalid object storage secret", lokiv1.ReasonInvalidObjectStorageSecret)
+	require.NoError(t, err)
+	require.Empty(t, s.Status.RunbookURL)
+
+	err = SetDegradedCondition(context.Background(), k, r, "Missing object storage secret", lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.NotEmpty(t, s.Status.RunbookURL)
+
+	err = SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Empty(t, s.Status.RunbookURL)
+}
+
+func TestUpdateCondition_WithoutRunbookURLs_NoURL(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		require.Empty(t, obj.(*lokiv1.LokiStack).Status.RunbookURL)
+		return nil
+	}
+
+	err := SetDegradedCondition(context.Background(), k, r, "Missing object storage secret", lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
@@ -0,0 +1,77 @@
+package status
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const messageUnschedulableAffinity = "Pods cannot be scheduled because of their node selector or affinity constraints: "
+
+var (
+	// affinityMarkers are the fragments the scheduler uses to report nodes filtered out by
+	// the pod node selector, node affinity or inter-pod (anti-)affinity.
+	affinityMarkers = []string{"affinity", "node selector"}
+	// resourceMarker is the fragment the scheduler uses to report nodes filtered out for
+	// lacking allocatable resources.
+	resourceMarker = "Insufficient "
+)
+
+// affinitySchedulingFailures returns the pending pods of the LokiStack mapped to their component
+// that cannot be scheduled only because of their node selector or (anti-)affinity constraints.
+// Pods the scheduler rejects for insufficient resources too are left out, since they may become
+// schedulable when the cluster scales.
+func affinitySchedulingFailures(ctx context.Context, k k8s.Client, stack *lokiv1.LokiStack, cs lokiv1.LokiStackComponentStatus) (map[string]string, error) {
+	failures := map[string]string{}
+	for component, psm := range componentPodStatus(cs) {
+		if len(psm[corev1.PodPending]) == 0 {
+			continue
+		}
+
+		var pods corev1.PodList
+		opts := []client.ListOption{
+			client.MatchingLabels(manifests.ComponentLabels(component, stack.Name)),
+			client.InNamespace(stack.Namespace),
+		}
+		if err := k.List(ctx, &pods, opts...); err != nil {
+			return nil, kverrors.Wrap(err, "failed to list pods for LokiStack component", "name", stack.Name, "component", component)
+		}
+
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodPending && isAffinitySchedulingFailure(pod) {
+				failures[pod.Name] = component
+			}
+		}
+	}
+
+	return failures, nil
+}
+
+// isAffinitySchedulingFailure returns true if the scheduler marked the pod unschedulable only
+// because of its node selector or (anti-)affinity constraints.
+func isAffinitySchedulingFailure(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type != corev1.PodScheduled || c.Status != corev1.ConditionFalse || c.Reason != corev1.PodReasonUnschedulable {
+			continue
+		}
+
+		if strings.Contains(c.Message, resourceMarker) {
+			return false
+		}
+
+		for _, m := range affinityMarkers {
+			if strings.Contains(c.Message, m) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
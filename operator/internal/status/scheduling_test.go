@@ -0,0 +1,162 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func unschedulablePod(name, message string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: message,
+				},
+			},
+		},
+	}
+}
+
+func TestIsAffinitySchedulingFailure(t *testing.T) {
+	table := []struct {
+		name    string
+		pod     corev1.Pod
+		wantAff bool
+	}{
+		{
+			name: "not yet scheduled",
+			pod:  corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}},
+		},
+		{
+			name:    "node affinity",
+			pod:     unschedulablePod("pod", "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector."),
+			wantAff: true,
+		},
+		{
+			name:    "pod anti-affinity",
+			pod:     unschedulablePod("pod", "0/3 nodes are available: 3 node(s) didn't match pod anti-affinity rules."),
+			wantAff: true,
+		},
+		{
+			name:    "node selector",
+			pod:     unschedulablePod("pod", "0/3 nodes are available: 3 node(s) didn't match node selector."),
+			wantAff: true,
+		},
+		{
+			name: "insufficient resources",
+			pod:  unschedulablePod("pod", "0/3 nodes are available: 3 Insufficient memory."),
+		},
+		{
+			name: "affinity and insufficient resources",
+			pod:  unschedulablePod("pod", "0/3 nodes are available: 1 Insufficient cpu, 2 node(s) didn't match Pod's node affinity/selector."),
+		},
+		{
+			name: "taints",
+			pod:  unschedulablePod("pod", "0/3 nodes are available: 3 node(s) had untolerated taint {dedicated: infra}."),
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.wantAff, isAffinitySchedulingFailure(tc.pod))
+		})
+	}
+}
+
+func TestRefresh_WhenPendingPodsUnschedulable(t *testing.T) {
+	table := []struct {
+		name       string
+		message    string
+		wantType   lokiv1.LokiStackConditionType
+		wantReason lokiv1.LokiStackConditionReason
+		wantMsg    string
+	}{
+		{
+			name:       "affinity",
+			message:    "0/3 nodes are available: 3 node(s) didn't match pod anti-affinity rules.",
+			wantType:   lokiv1.ConditionDegraded,
+			wantReason: lokiv1.ReasonUnschedulableAffinity,
+			wantMsg:    "Pods cannot be scheduled because of their node selector or affinity constraints: my-stack-ingester-0 (ingester), my-stack-ingester-1 (ingester)",
+		},
+		{
+			name:       "insufficient resources",
+			message:    "0/3 nodes are available: 3 Insufficient cpu.",
+			wantType:   lokiv1.ConditionPending,
+			wantReason: lokiv1.ReasonPendingComponents,
+			wantMsg:    messagePending,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			k.ListStub = func(_ context.Context, l client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+
+				pods := []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+						Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+					},
+				}
+
+				ingester := labels.Set(manifests.ComponentLabels(manifests.LabelIngesterComponent, s.Name))
+				if lo.LabelSelector.Matches(ingester) {
+					pods = []corev1.Pod{
+						unschedulablePod("my-stack-ingester-1", tc.message),
+						unschedulablePod("my-stack-ingester-0", tc.message),
+					}
+				}
+
+				k.SetClientObjectList(l, &corev1.PodList{Items: pods})
+				return nil
+			}
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := Refresh(context.Background(), k, r)
+			require.NoError(t, err)
+
+			condition := ConditionsMap(&s)[string(tc.wantType)]
+			require.Equal(t, metav1.ConditionTrue, condition.Status)
+			require.Equal(t, string(tc.wantReason), condition.Reason)
+			require.Equal(t, tc.wantMsg, condition.Message)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AnnotationSchemaChangeApproved is the LokiStack annotation an administrator sets to "true"
+// to acknowledge an upcoming storage schema change.
+const AnnotationSchemaChangeApproved = "loki.grafana.com/schema-change-approved"
+
+// pendingSchemaChange returns the applied storage schema becoming effective after now, if any.
+// It returns false if the change is already approved or approval is not required.
+func pendingSchemaChange(stack *lokiv1.LokiStack, now time.Time) (lokiv1.ObjectStorageSchema, bool) {
+	if !options.RequireSchemaChangeApproval {
+		return lokiv1.ObjectStorageSchema{}, false
+	}
+
+	if stack.Annotations[AnnotationSchemaChangeApproved] == "true" {
+		return lokiv1.ObjectStorageSchema{}, false
+	}
+
+	for _, schema := range stack.Status.Storage.Schemas {
+		date, err := schema.EffectiveDate.UTCTime()
+		if err != nil {
+			continue
+		}
+
+		if date.After(now) {
+			return schema, true
+		}
+	}
+
+	return lokiv1.ObjectStorageSchema{}, false
+}
+
+// setSchemaChangeApprovalPendingCondition updates or appends the condition Pending asking
+// for the approval of the given schema change. In addition it resets all other Status
+// conditions to false.
+func setSchemaChangeApprovalPendingCondition(ctx context.Context, k k8s.Client, req ctrl.Request, schema lokiv1.ObjectStorageSchema) error {
+	pending := metav1.Condition{
+		Type: string(lokiv1.ConditionPending),
+		Message: fmt.Sprintf("Storage schema change to version %s effective %s awaits approval: set annotation %s=true",
+			schema.Version, schema.EffectiveDate, AnnotationSchemaChangeApproved),
+		Reason: string(lokiv1.ReasonPendingSchemaChangeApproval),
+	}
+
+	return updateCondition(ctx, k, req, pending, MutualExclusionPolicy)
+}
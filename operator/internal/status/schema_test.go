@@ -0,0 +1,133 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRefresh_SchemaChangeApproval(t *testing.T) {
+	opts := DefaultOptions()
+	opts.RequireSchemaChangeApproval = true
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	table := []struct {
+		name        string
+		annotations map[string]string
+		wantType    lokiv1.LokiStackConditionType
+		wantReason  lokiv1.LokiStackConditionReason
+	}{
+		{
+			name:       "ack absent",
+			wantType:   lokiv1.ConditionPending,
+			wantReason: lokiv1.ReasonPendingSchemaChangeApproval,
+		},
+		{
+			name: "ack present",
+			annotations: map[string]string{
+				AnnotationSchemaChangeApproved: "true",
+			},
+			wantType:   lokiv1.ConditionReady,
+			wantReason: lokiv1.ReasonReadyComponents,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-stack",
+					Namespace:   "some-ns",
+					Annotations: tc.annotations,
+				},
+				Status: lokiv1.LokiStackStatus{
+					Storage: lokiv1.LokiStackStorageStatus{
+						Schemas: []lokiv1.ObjectStorageSchema{
+							{
+								Version:       lokiv1.ObjectStorageSchemaV11,
+								EffectiveDate: "2020-10-11",
+							},
+							{
+								Version:       lokiv1.ObjectStorageSchemaV12,
+								EffectiveDate: "2999-10-11",
+							},
+						},
+					},
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+
+			var conditions []metav1.Condition
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions = obj.(*lokiv1.LokiStack).Status.Conditions
+				return nil
+			}
+
+			err := Refresh(context.Background(), k, r)
+			require.NoError(t, err)
+
+			require.Len(t, conditions, 1)
+			require.Equal(t, string(tc.wantType), conditions[0].Type)
+			require.Equal(t, string(tc.wantReason), conditions[0].Reason)
+			require.Equal(t, metav1.ConditionTrue, conditions[0].Status)
+		})
+	}
+}
+
+func TestRefresh_WhenApprovalNotRequired_SetReady(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Storage: lokiv1.LokiStackStorageStatus{
+				Schemas: []lokiv1.ObjectStorageSchema{
+					{
+						Version:       lokiv1.ObjectStorageSchemaV12,
+						EffectiveDate: "2999-10-11",
+					},
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+
+	var conditions []metav1.Condition
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		conditions = obj.(*lokiv1.LokiStack).Status.Conditions
+		return nil
+	}
+
+	err := Refresh(context.Background(), k, r)
+	require.NoError(t, err)
+
+	require.Len(t, conditions, 1)
+	require.Equal(t, string(lokiv1.ConditionReady), conditions[0].Type)
+}
@@ -0,0 +1,57 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// conditionSeverity ranks the managed condition types from the least to the most severe.
+// Condition types not listed, e.g. those owned by external controllers, have no severity.
+var conditionSeverity = map[string]int{
+	string(lokiv1.ConditionReady):      1,
+	string(lokiv1.ConditionRecovering): 2,
+	string(lokiv1.ConditionPending):    3,
+	string(lokiv1.ConditionWarning):    4,
+	string(lokiv1.ConditionDegraded):   5,
+	string(lokiv1.ConditionFailed):     6,
+}
+
+// activeSeverity returns the severity of the most severe active condition.
+func activeSeverity(conditions []metav1.Condition) int {
+	var severity int
+	for _, c := range conditions {
+		if c.Status == metav1.ConditionTrue && conditionSeverity[c.Type] > severity {
+			severity = conditionSeverity[c.Type]
+		}
+	}
+
+	return severity
+}
+
+// SetConditionIfMoreSevere sets the condition to true like SetCondition, but only if it is at
+// least as severe as the most severe active condition of the LokiStack according to
+// conditionSeverity. Thus a later call for e.g. a Warning never overwrites an active Degraded
+// or Failed condition. State conditions are set with MutualExclusionPolicy and all other
+// conditions, e.g. Warning, with CoexistPolicy.
+func SetConditionIfMoreSevere(ctx context.Context, k k8s.Client, req ctrl.Request, condition metav1.Condition) error {
+	condition.Status = metav1.ConditionTrue
+
+	policy := CoexistPolicy
+	if isStateCondition(condition.Type) {
+		policy = MutualExclusionPolicy
+	}
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		if conditionSeverity[condition.Type] < activeSeverity(stack.Status.Conditions) {
+			return false
+		}
+
+		recordAssertion(req.NamespacedName, condition.Type, now())
+		return setStackCondition(stack, condition, policy)
+	})
+}
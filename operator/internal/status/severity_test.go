@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetConditionIfMoreSevere(t *testing.T) {
+	degraded := metav1.Condition{
+		Type:    string(lokiv1.ConditionDegraded),
+		Reason:  string(lokiv1.ReasonMissingObjectStorageSecret),
+		Message: "Missing object storage secret",
+		Status:  metav1.ConditionTrue,
+	}
+	warning := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonWALDiskPressure),
+		Message: "some warning",
+	}
+	failed := metav1.Condition{
+		Type:    string(lokiv1.ConditionFailed),
+		Reason:  string(lokiv1.ReasonFailedComponents),
+		Message: messageFailed,
+	}
+	otherDegraded := metav1.Condition{
+		Type:    string(lokiv1.ConditionDegraded),
+		Reason:  string(lokiv1.ReasonInvalidReplicationConfiguration),
+		Message: "Invalid replication factor",
+	}
+
+	table := []struct {
+		name      string
+		condition metav1.Condition
+		wantWrite bool
+	}{
+		{
+			name:      "de-escalate to warning",
+			condition: warning,
+		},
+		{
+			name:      "de-escalate to ready",
+			condition: metav1.Condition{Type: string(lokiv1.ConditionReady), Reason: string(lokiv1.ReasonReadyComponents), Message: messageReady},
+		},
+		{
+			name:      "same severity",
+			condition: otherDegraded,
+			wantWrite: true,
+		},
+		{
+			name:      "escalate to failed",
+			condition: failed,
+			wantWrite: true,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: []metav1.Condition{degraded},
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := SetConditionIfMoreSevere(context.Background(), k, r, tc.condition)
+			require.NoError(t, err)
+
+			conditions := ConditionsMap(&s)
+			if !tc.wantWrite {
+				require.Zero(t, sw.UpdateCallCount())
+				require.Equal(t, degraded, conditions[degraded.Type])
+				return
+			}
+
+			require.Equal(t, 1, sw.UpdateCallCount())
+			c := conditions[tc.condition.Type]
+			require.Equal(t, metav1.ConditionTrue, c.Status)
+			require.Equal(t, tc.condition.Reason, c.Reason)
+			require.Equal(t, tc.condition.Message, c.Message)
+			if tc.condition.Type != degraded.Type {
+				require.Equal(t, metav1.ConditionFalse, conditions[degraded.Type].Status)
+			}
+		})
+	}
+}
+
+func TestSetConditionIfMoreSevere_WhenWarningOverReady_Coexist(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReady),
+					Reason:  string(lokiv1.ReasonReadyComponents),
+					Message: messageReady,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := SetConditionIfMoreSevere(context.Background(), k, r, metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonWALDiskPressure),
+		Message: "some warning",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionWarning)].Status)
+}
@@ -0,0 +1,75 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const messageMultipleIssues = "Multiple issues; see details"
+
+// DetectIssueSprawl sets the condition Warning alongside the other conditions if more distinct
+// Degraded and Failed conditions are active on the LokiStack and its tenants than the configured
+// IssueSprawlThreshold allows, e.g. after a broken configuration change. The message lists the
+// active conditions to help prioritizing them. Once the count falls back to the threshold, the
+// condition is cleared.
+func DetectIssueSprawl(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	threshold := options.IssueSprawlThreshold
+	if threshold == 0 {
+		return nil
+	}
+
+	var stack lokiv1.LokiStack
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+	}
+
+	issues := activeIssues(&stack)
+	if len(issues) <= threshold {
+		return clearWarning(ctx, k, req, lokiv1.ReasonMultipleIssues, "")
+	}
+
+	return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+		Reason:  lokiv1.ReasonMultipleIssues,
+		Message: fmt.Sprintf("%s: %s", messageMultipleIssues, strings.Join(issues, ", ")),
+	})
+}
+
+// activeIssues returns the sorted descriptions of the active Degraded and Failed conditions
+// of the LokiStack and its tenants.
+func activeIssues(stack *lokiv1.LokiStack) []string {
+	issues := conditionIssues("", stack.Status.Conditions)
+	for tenant, ts := range stack.Status.Tenants {
+		issues = append(issues, conditionIssues(fmt.Sprintf("tenant %s ", tenant), ts.Conditions)...)
+	}
+	sort.Strings(issues)
+
+	return issues
+}
+
+func conditionIssues(scope string, conditions []metav1.Condition) []string {
+	var issues []string
+	for _, c := range conditions {
+		if c.Status != metav1.ConditionTrue {
+			continue
+		}
+
+		if c.Type == string(lokiv1.ConditionDegraded) || c.Type == string(lokiv1.ConditionFailed) {
+			issues = append(issues, fmt.Sprintf("%s%s/%s", scope, c.Type, c.Reason))
+		}
+	}
+
+	return issues
+}
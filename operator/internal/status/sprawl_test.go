@@ -0,0 +1,155 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDetectIssueSprawl(t *testing.T) {
+	degraded := func(reason lokiv1.LokiStackConditionReason) metav1.Condition {
+		return metav1.Condition{
+			Type:   string(lokiv1.ConditionDegraded),
+			Reason: string(reason),
+			Status: metav1.ConditionTrue,
+		}
+	}
+	failed := metav1.Condition{
+		Type:   string(lokiv1.ConditionFailed),
+		Reason: string(lokiv1.ReasonFailedComponents),
+		Status: metav1.ConditionTrue,
+	}
+
+	summary := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonMultipleIssues),
+		Message: "Multiple issues; see details: Degraded/MissingObjectStorageSecret, tenant application Degraded/InvalidTenantsConfiguration, tenant audit Failed/FailedComponents",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name        string
+		conditions  []metav1.Condition
+		tenants     map[string]lokiv1.LokiStackTenantStatus
+		wantUpdate  bool
+		wantWarning metav1.ConditionStatus
+	}{
+		{
+			name:       "below threshold",
+			conditions: []metav1.Condition{degraded(lokiv1.ReasonMissingObjectStorageSecret)},
+		},
+		{
+			name:       "at threshold",
+			conditions: []metav1.Condition{degraded(lokiv1.ReasonMissingObjectStorageSecret)},
+			tenants: map[string]lokiv1.LokiStackTenantStatus{
+				"application": {Conditions: []metav1.Condition{degraded(lokiv1.ReasonInvalidTenantsConfiguration)}},
+				"audit":       {Conditions: []metav1.Condition{{Type: failed.Type, Reason: failed.Reason, Status: metav1.ConditionFalse}}},
+			},
+		},
+		{
+			name:       "above threshold",
+			conditions: []metav1.Condition{degraded(lokiv1.ReasonMissingObjectStorageSecret)},
+			tenants: map[string]lokiv1.LokiStackTenantStatus{
+				"application": {Conditions: []metav1.Condition{degraded(lokiv1.ReasonInvalidTenantsConfiguration)}},
+				"audit":       {Conditions: []metav1.Condition{failed}},
+			},
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionTrue,
+		},
+		{
+			name:       "still above threshold",
+			conditions: []metav1.Condition{degraded(lokiv1.ReasonMissingObjectStorageSecret), summary},
+			tenants: map[string]lokiv1.LokiStackTenantStatus{
+				"application": {Conditions: []metav1.Condition{degraded(lokiv1.ReasonInvalidTenantsConfiguration)}},
+				"audit":       {Conditions: []metav1.Condition{failed}},
+			},
+		},
+		{
+			name:       "back at threshold",
+			conditions: []metav1.Condition{degraded(lokiv1.ReasonMissingObjectStorageSecret), summary},
+			tenants: map[string]lokiv1.LokiStackTenantStatus{
+				"application": {Conditions: []metav1.Condition{degraded(lokiv1.ReasonInvalidTenantsConfiguration)}},
+			},
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() { Configure(DefaultOptions()) })
+
+			opts := DefaultOptions()
+			opts.IssueSprawlThreshold = 2
+			Configure(opts)
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+					Warnings:   listedWarnings("", tc.conditions...),
+					Tenants:    tc.tenants,
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				warning := ConditionsMap(obj.(*lokiv1.LokiStack))[string(lokiv1.ConditionWarning)]
+				require.Equal(t, tc.wantWarning, warning.Status)
+				require.Equal(t, summary.Reason, warning.Reason)
+				require.Equal(t, summary.Message, warning.Message)
+				return nil
+			}
+
+			err := DetectIssueSprawl(context.Background(), k, r)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestDetectIssueSprawl_WhenDisabled_DoNothing(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, _ := setupFakes(&s)
+
+	err := DetectIssueSprawl(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Zero(t, k.GetCallCount())
+}
@@ -0,0 +1,63 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const messageReconcilingAfterRestart = "Reconciling after operator restart"
+
+// writeHistory records whether this operator process wrote the status of a LokiStack.
+type writeHistory struct {
+	written bool
+}
+
+var writes = newStackStore[writeHistory]()
+
+func recordWrite(key types.NamespacedName) {
+	writes.update(key, func(h *writeHistory) {
+		h.written = true
+	})
+}
+
+func hasWritten(key types.NamespacedName) bool {
+	var written bool
+	writes.read(key, func(h *writeHistory) {
+		written = h.written
+	})
+	return written
+}
+
+// MarkUnknownOnStartup sets the status of the active managed condition describing the state of
+// the LokiStack, e.g. Ready or Degraded, to Unknown. On operator start-up these conditions still
+// reflect the previous operator run until the first reconcile completes. It is expected to be
+// called once per LokiStack on start-up and does nothing if this operator process already wrote
+// the status of the LokiStack, thus never hides the result of a reconcile.
+func MarkUnknownOnStartup(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		if hasWritten(req.NamespacedName) {
+			return false
+		}
+
+		var changed bool
+		for i := range stack.Status.Conditions {
+			c := &stack.Status.Conditions[i]
+			if !isStateCondition(c.Type) || c.Status != metav1.ConditionTrue {
+				continue
+			}
+
+			c.Status = metav1.ConditionUnknown
+			c.Message = messageReconcilingAfterRestart
+			c.LastTransitionTime = metav1.NewTime(now())
+			changed = true
+		}
+
+		return changed
+	})
+}
@@ -0,0 +1,119 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMarkUnknownOnStartup(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReady),
+					Reason:  string(lokiv1.ReasonReadyComponents),
+					Message: messageReady,
+					Status:  metav1.ConditionTrue,
+				},
+				{
+					Type:    string(lokiv1.ConditionPending),
+					Reason:  string(lokiv1.ReasonPendingComponents),
+					Message: messagePending,
+					Status:  metav1.ConditionFalse,
+				},
+				{
+					Type:    string(lokiv1.ConditionWarning),
+					Reason:  string(lokiv1.ReasonWALDiskPressure),
+					Message: "some warning",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	writes.delete(r.NamespacedName)
+	t.Cleanup(func() { writes.delete(r.NamespacedName) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := MarkUnknownOnStartup(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionUnknown, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, string(lokiv1.ReasonReadyComponents), conditions[string(lokiv1.ConditionReady)].Reason)
+	require.Equal(t, messageReconcilingAfterRestart, conditions[string(lokiv1.ConditionReady)].Message)
+	require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionPending)].Status)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionWarning)].Status)
+
+	// The first reconcile replaces the unknown condition
+	err = SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(&s)[string(lokiv1.ConditionReady)].Status)
+}
+
+func TestMarkUnknownOnStartup_WhenAlreadyWritten_DoNothing(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionPending),
+					Reason:  string(lokiv1.ReasonPendingComponents),
+					Message: messagePending,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+	writes.delete(r.NamespacedName)
+	t.Cleanup(func() { writes.delete(r.NamespacedName) })
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+
+	err = MarkUnknownOnStartup(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(&s)[string(lokiv1.ConditionReady)].Status)
+}
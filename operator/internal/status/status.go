@@ -14,12 +14,33 @@ import (
 
 // Refresh executes an aggregate update of the LokiStack Status struct, i.e.
 // - It recreates the Status.Components pod status map per component.
+// - It counts the reconciliations since the LokiStack was last ready.
+// - It clears Warning and Degraded conditions older than the configured condition TTL.
+// - It sets the Status.Condition Flapping if the conditions transition too frequently.
+// - It sets the Status.Condition Warning if too many Degraded and Failed conditions are active.
+// - It sets the Status.Condition PartiallyAvailable if some but not all pods are running, reset along Ready.
 // - It sets the appropriate Status.Condition to true that matches the pod status maps.
+// - It sets the Status.Condition Degraded if pending pods cannot be scheduled because of their affinity.
+// - It sets the Status.Condition Recovering instead of Ready within the recovery window.
+// - It ignores the write path pod status maps while the Status.Condition ReadOnly is true.
+// - It keeps the Status.Condition Pending while an upcoming storage schema change awaits approval.
 func Refresh(ctx context.Context, k k8s.Client, req ctrl.Request) error {
 	if err := SetComponentsStatus(ctx, k, req); err != nil {
 		return err
 	}
 
+	if err := ExpireStaleConditions(ctx, k, req); err != nil {
+		return err
+	}
+
+	if err := DetectFlapping(ctx, k, req); err != nil {
+		return err
+	}
+
+	if err := DetectIssueSprawl(ctx, k, req); err != nil {
+		return err
+	}
+
 	var s lokiv1.LokiStack
 	if err := k.Get(ctx, req.NamespacedName, &s); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -28,43 +49,43 @@ func Refresh(ctx context.Context, k k8s.Client, req ctrl.Request) error {
 		return kverrors.Wrap(err, "failed to lookup lokistack", "name", req.NamespacedName)
 	}
 
+	ObserveReconcile(&s)
+
 	cs := s.Status.Components
+	if isReadOnly(&s) {
+		// Rejecting writes is expected, thus ignore the write path
+		cs.Distributor = nil
+	}
+
+	// Ready and Recovering reset PartiallyAvailable, thus only evaluate it while some pods are not running
+	if len(podsInPhases(cs, corev1.PodFailed, corev1.PodUnknown, corev1.PodPending)) != 0 {
+		if err := setPartiallyAvailableCondition(ctx, k, req, &s, cs); err != nil {
+			return err
+		}
+	}
 
 	// Check for failed pods first
-	failed := len(cs.Compactor[corev1.PodFailed]) +
-		len(cs.Distributor[corev1.PodFailed]) +
-		len(cs.Ingester[corev1.PodFailed]) +
-		len(cs.Querier[corev1.PodFailed]) +
-		len(cs.QueryFrontend[corev1.PodFailed]) +
-		len(cs.Gateway[corev1.PodFailed]) +
-		len(cs.IndexGateway[corev1.PodFailed]) +
-		len(cs.Ruler[corev1.PodFailed])
-
-	unknown := len(cs.Compactor[corev1.PodUnknown]) +
-		len(cs.Distributor[corev1.PodUnknown]) +
-		len(cs.Ingester[corev1.PodUnknown]) +
-		len(cs.Querier[corev1.PodUnknown]) +
-		len(cs.QueryFrontend[corev1.PodUnknown]) +
-		len(cs.Gateway[corev1.PodUnknown]) +
-		len(cs.IndexGateway[corev1.PodUnknown]) +
-		len(cs.Ruler[corev1.PodUnknown])
-
-	if failed != 0 || unknown != 0 {
-		return SetFailedCondition(ctx, k, req)
+	if failed := podsInPhases(cs, corev1.PodFailed, corev1.PodUnknown); len(failed) != 0 {
+		return SetFailedCondition(ctx, k, req, failed...)
 	}
 
 	// Check for pending pods
-	pending := len(cs.Compactor[corev1.PodPending]) +
-		len(cs.Distributor[corev1.PodPending]) +
-		len(cs.Ingester[corev1.PodPending]) +
-		len(cs.Querier[corev1.PodPending]) +
-		len(cs.QueryFrontend[corev1.PodPending]) +
-		len(cs.Gateway[corev1.PodPending]) +
-		len(cs.IndexGateway[corev1.PodPending]) +
-		len(cs.Ruler[corev1.PodPending])
-
-	if pending != 0 {
+	if len(podsInPhases(cs, corev1.PodPending)) != 0 {
+		failures, err := affinitySchedulingFailures(ctx, k, &s, cs)
+		if err != nil {
+			return err
+		}
+		if len(failures) != 0 {
+			msg := messageUnschedulableAffinity + FormatMap(failures, "%s (%s)")
+			return SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonUnschedulableAffinity)
+		}
+
 		return SetPendingCondition(ctx, k, req)
 	}
-	return SetReadyCondition(ctx, k, req)
+
+	if schema, ok := pendingSchemaChange(&s, now().UTC()); ok {
+		return setSchemaChangeApprovalPendingCondition(ctx, k, req, schema)
+	}
+
+	return setReadyOrRecoveringCondition(ctx, k, req, &s)
 }
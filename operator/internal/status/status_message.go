@@ -0,0 +1,17 @@
+package status
+
+import (
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+// statusMessage returns the message of the active phase condition of the LokiStack, i.e. the
+// condition returned by Phase, or an empty string. It is stamped into Status.Message on every
+// status write.
+func statusMessage(stack *lokiv1.LokiStack) string {
+	c, ok := phaseCondition(stack)
+	if !ok {
+		return ""
+	}
+
+	return c.Message
+}
@@ -0,0 +1,59 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpdateCondition_StampsStatusMessage(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		s.Status = obj.(*lokiv1.LokiStack).Status
+		return nil
+	}
+
+	err := SetPendingCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, messagePending, s.Status.Message)
+
+	err = SetDegradedCondition(context.Background(), k, r, "Missing object storage secret", lokiv1.ReasonMissingObjectStorageSecret)
+	require.NoError(t, err)
+	require.Equal(t, "Missing object storage secret", s.Status.Message)
+
+	// A coexisting warning does not replace the message of the active phase condition
+	err = SetCondition(context.Background(), k, r, lokiv1.ConditionWarning, "some warning", lokiv1.ReasonWALDiskPressure, CoexistPolicy)
+	require.NoError(t, err)
+	require.Equal(t, "Missing object storage secret", s.Status.Message)
+
+	err = SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Equal(t, messageReady, s.Status.Message)
+
+	// No active phase condition clears the message
+	err = ReconcileConditions(context.Background(), k, r, nil, MutualExclusionPolicy)
+	require.NoError(t, err)
+	require.Empty(t, s.Status.Message)
+}
@@ -0,0 +1,42 @@
+// Package statustest provides helpers for asserting LokiStack status conditions in tests.
+// It must only be imported by _test.go files.
+package statustest
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestingT is the subset of testing.TB used to report assertion failures.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+var ignoreTransitionTime = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+
+// AssertCondition asserts that the LokiStack status contains a condition of the expected type
+// equal to expected, ignoring LastTransitionTime. On mismatch it reports a readable diff.
+func AssertCondition(t TestingT, stack *lokiv1.LokiStack, expected metav1.Condition) bool {
+	t.Helper()
+
+	var types []string
+	for _, c := range stack.Status.Conditions {
+		if c.Type != expected.Type {
+			types = append(types, c.Type)
+			continue
+		}
+
+		if diff := cmp.Diff(expected, c, ignoreTransitionTime); diff != "" {
+			t.Errorf("condition %s mismatch (-want +got):\n%s", expected.Type, diff)
+			return false
+		}
+		return true
+	}
+
+	t.Errorf("condition %s not found, got condition types %v", expected.Type, types)
+	return false
+}
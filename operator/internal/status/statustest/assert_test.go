@@ -0,0 +1,94 @@
+package statustest_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status/statustest"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertCondition(t *testing.T) {
+	stack := &lokiv1.LokiStack{
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionReady),
+					Reason:             string(lokiv1.ReasonReadyComponents),
+					Message:            "All components ready",
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			},
+		},
+	}
+
+	table := []struct {
+		name     string
+		expected metav1.Condition
+		want     bool
+		wantErr  string
+	}{
+		{
+			name: "equal ignoring last transition time",
+			expected: metav1.Condition{
+				Type:    string(lokiv1.ConditionReady),
+				Reason:  string(lokiv1.ReasonReadyComponents),
+				Message: "All components ready",
+				Status:  metav1.ConditionTrue,
+			},
+			want: true,
+		},
+		{
+			name: "different status",
+			expected: metav1.Condition{
+				Type:    string(lokiv1.ConditionReady),
+				Reason:  string(lokiv1.ReasonReadyComponents),
+				Message: "All components ready",
+				Status:  metav1.ConditionFalse,
+			},
+			wantErr: "condition Ready mismatch (-want +got)",
+		},
+		{
+			name: "missing type",
+			expected: metav1.Condition{
+				Type:   string(lokiv1.ConditionDegraded),
+				Status: metav1.ConditionTrue,
+			},
+			wantErr: "condition Degraded not found, got condition types [Ready]",
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := &recorder{}
+
+			ok := statustest.AssertCondition(r, stack, tc.expected)
+			require.Equal(t, tc.want, ok)
+
+			if tc.want {
+				require.Empty(t, r.errors)
+				return
+			}
+
+			require.Len(t, r.errors, 1)
+			require.Contains(t, r.errors[0], tc.wantErr)
+		})
+	}
+}
@@ -14,6 +14,10 @@ import (
 
 // SetStorageSchemaStatus updates the storage status component
 func SetStorageSchemaStatus(ctx context.Context, k k8s.Client, req ctrl.Request, schemas []lokiv1.ObjectStorageSchema) error {
+	if options.Disabled {
+		return nil
+	}
+
 	var s lokiv1.LokiStack
 	if err := k.Get(ctx, req.NamespacedName, &s); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -26,5 +30,10 @@ func SetStorageSchemaStatus(ctx context.Context, k k8s.Client, req ctrl.Request,
 		Schemas: schemas,
 	}
 
-	return k.Status().Update(ctx, &s, &client.UpdateOptions{})
+	if err := k.Status().Update(ctx, &s, &client.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	recordOwnChange(req.NamespacedName, s.ResourceVersion)
+	return nil
 }
@@ -0,0 +1,82 @@
+package status
+
+import (
+	"sync"
+
+	"github.com/grafana/loki/operator/internal/metrics"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// stackStore holds state shared across reconciliations per LokiStack.
+//
+// The controller may reconcile distinct LokiStacks concurrently if configured with
+// MaxConcurrentReconciles > 1, while a single LokiStack is never reconciled concurrently.
+// The store does not rely on the latter: every access to an entry happens under a
+// store-wide lock, thus all methods are safe for concurrent use. Callers must not retain
+// the entry passed to the callbacks beyond their return.
+type stackStore[T any] struct {
+	mu      sync.Mutex
+	entries map[types.NamespacedName]*T
+}
+
+// stores lists all stores created by newStackStore, thus Forget prunes the state of every feature.
+var (
+	storesMu sync.Mutex
+	stores   []interface{ delete(types.NamespacedName) }
+)
+
+func newStackStore[T any]() *stackStore[T] {
+	s := &stackStore[T]{entries: map[types.NamespacedName]*T{}}
+
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	stores = append(stores, s)
+
+	return s
+}
+
+// Forget removes the entries of the LokiStack from all stores and its per-LokiStack metrics.
+// The controller calls it for deleted LokiStacks, so that the state does not grow with every
+// LokiStack ever reconciled.
+func Forget(key types.NamespacedName) {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	for _, s := range stores {
+		s.delete(key)
+	}
+
+	metrics.DeleteConditionTransitions(key)
+}
+
+// update calls fn with the entry of the LokiStack, creating a zero entry if none exists.
+func (s *stackStore[T]) update(key types.NamespacedName, fn func(*T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		e = new(T)
+		s.entries[key] = e
+	}
+	fn(e)
+}
+
+// read calls fn with the entry of the LokiStack if one exists.
+func (s *stackStore[T]) read(key types.NamespacedName, fn func(*T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		fn(e)
+	}
+}
+
+// delete removes the entry of the LokiStack.
+func (s *stackStore[T]) delete(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
@@ -0,0 +1,98 @@
+package status
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStackStore_ConcurrentUpdatesOfDistinctStacks(t *testing.T) {
+	const (
+		stacks  = 10
+		updates = 100
+	)
+
+	store := newStackStore[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < stacks; i++ {
+		key := types.NamespacedName{Name: fmt.Sprintf("stack-%d", i), Namespace: "some-ns"}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < updates; j++ {
+				store.update(key, func(n *int) { *n++ })
+				store.read(key, func(n *int) { _ = *n })
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < stacks; i++ {
+		key := types.NamespacedName{Name: fmt.Sprintf("stack-%d", i), Namespace: "some-ns"}
+
+		var got int
+		store.read(key, func(n *int) { got = *n })
+		require.Equal(t, updates, got)
+	}
+}
+
+func TestStatusState_ConcurrentUpdatesOfDistinctStacks(t *testing.T) {
+	const stacks = 10
+
+	var wg sync.WaitGroup
+	for i := 0; i < stacks; i++ {
+		key := types.NamespacedName{Name: fmt.Sprintf("stack-%d", i), Namespace: "some-ns"}
+		t.Cleanup(func() {
+			conflicts.delete(key)
+			assertions.delete(key)
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			now := time.Now()
+			for j := 0; j < 100; j++ {
+				observeChange(key, fmt.Sprint(j), now, time.Minute)
+				recordOwnChange(key, fmt.Sprint(j+1))
+				recordAssertion(key, "Warning", now)
+				lastAsserted(key, "Warning")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestForget(t *testing.T) {
+	key := types.NamespacedName{Name: "my-stack", Namespace: "some-ns"}
+	other := types.NamespacedName{Name: "other-stack", Namespace: "some-ns"}
+	t.Cleanup(func() { Forget(other) })
+
+	now := time.Now()
+	for _, k := range []types.NamespacedName{key, other} {
+		observeChange(k, "1", now, time.Minute)
+		recordAssertion(k, "Warning", now)
+		consecutiveErrors.update(k, func(n *int) { *n++ })
+	}
+
+	Forget(key)
+
+	require.True(t, lastAsserted(key, "Warning").IsZero())
+	require.False(t, lastAsserted(other, "Warning").IsZero())
+
+	var errs int
+	consecutiveErrors.read(key, func(n *int) { errs = *n })
+	require.Zero(t, errs)
+	consecutiveErrors.read(other, func(n *int) { errs = *n })
+	require.Equal(t, 1, errs)
+
+	var found bool
+	conflicts.read(key, func(*churnHistory) { found = true })
+	require.False(t, found)
+}
@@ -0,0 +1,146 @@
+package status
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/grafana/loki/operator/internal/metrics"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ConditionChange describes an actual transition of a LokiStack condition written by this package.
+type ConditionChange struct {
+	// Stack is the namespaced name of the LokiStack.
+	Stack types.NamespacedName
+	// Previous is the condition before the change or nil if the condition is new.
+	Previous *metav1.Condition
+	// Current is the condition after the change.
+	Current metav1.Condition
+}
+
+// Subscription receives the condition and component changes of all LokiStacks. Delivery never
+// blocks status writes: changes not fitting into the bounded buffers are dropped and counted.
+type Subscription struct {
+	ch         chan ConditionChange
+	components chan ComponentChange
+	dropped    atomic.Uint64
+}
+
+// C returns the channel receiving the condition changes. It is closed on Unsubscribe.
+func (s *Subscription) C() <-chan ConditionChange {
+	return s.ch
+}
+
+// Components returns the channel receiving the component changes. It is closed on Unsubscribe.
+func (s *Subscription) Components() <-chan ComponentChange {
+	return s.components
+}
+
+// Dropped returns the number of condition and component changes dropped because the buffers were full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+var subscribers = struct {
+	sync.RWMutex
+	set map[*Subscription]struct{}
+}{
+	set: map[*Subscription]struct{}{},
+}
+
+// Subscribe registers a new subscription buffering up to buffer condition changes and
+// buffer component changes.
+func Subscribe(buffer int) *Subscription {
+	s := &Subscription{
+		ch:         make(chan ConditionChange, buffer),
+		components: make(chan ComponentChange, buffer),
+	}
+
+	subscribers.Lock()
+	defer subscribers.Unlock()
+	subscribers.set[s] = struct{}{}
+
+	return s
+}
+
+// Unsubscribe removes the subscription and closes its channel.
+func Unsubscribe(s *Subscription) {
+	subscribers.Lock()
+	defer subscribers.Unlock()
+
+	if _, ok := subscribers.set[s]; !ok {
+		return
+	}
+
+	delete(subscribers.set, s)
+	close(s.ch)
+	close(s.components)
+}
+
+// publish delivers the changes to all subscriptions without blocking.
+func publish(changes []ConditionChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	subscribers.RLock()
+	defer subscribers.RUnlock()
+
+	for s := range subscribers.set {
+		for _, c := range changes {
+			select {
+			case s.ch <- c:
+			default:
+				s.dropped.Add(1)
+				metrics.IncConditionChangesDropped()
+			}
+		}
+	}
+}
+
+// publishComponentChanges delivers the component changes to all subscriptions without blocking.
+func publishComponentChanges(changes []ComponentChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	subscribers.RLock()
+	defer subscribers.RUnlock()
+
+	for s := range subscribers.set {
+		for _, c := range changes {
+			select {
+			case s.components <- c:
+			default:
+				s.dropped.Add(1)
+			}
+		}
+	}
+}
+
+// conditionChanges returns a change for every condition in written that is new or differs
+// in status, reason or message from the condition of the same type in previous.
+func conditionChanges(key types.NamespacedName, previous, written []metav1.Condition) []ConditionChange {
+	before := make(map[string]metav1.Condition, len(previous))
+	for _, c := range previous {
+		before[c.Type] = c
+	}
+
+	var changes []ConditionChange
+	for _, c := range written {
+		change := ConditionChange{Stack: key, Current: c}
+
+		if p, ok := before[c.Type]; ok {
+			if p.Status == c.Status && p.Reason == c.Reason && p.Message == c.Message {
+				continue
+			}
+			change.Previous = &p
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
@@ -0,0 +1,102 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSubscribe_ReceivesConditionChanges(t *testing.T) {
+	sub := Subscribe(10)
+	t.Cleanup(func() { Unsubscribe(sub) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionPending),
+					Reason:  string(lokiv1.ReasonPendingComponents),
+					Message: messagePending,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	err := SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+
+	var changes []ConditionChange
+	for len(sub.C()) > 0 {
+		changes = append(changes, <-sub.C())
+	}
+
+	require.Len(t, changes, 2)
+	require.Equal(t, r.NamespacedName, changes[0].Stack)
+	require.Equal(t, string(lokiv1.ConditionPending), changes[0].Current.Type)
+	require.Equal(t, metav1.ConditionFalse, changes[0].Current.Status)
+	require.NotNil(t, changes[0].Previous)
+	require.Equal(t, metav1.ConditionTrue, changes[0].Previous.Status)
+	require.Equal(t, string(lokiv1.ConditionReady), changes[1].Current.Type)
+	require.Nil(t, changes[1].Previous)
+
+	// Setting the same condition again is not a transition
+	err = SetReadyCondition(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Len(t, sub.C(), 0)
+	require.Zero(t, sub.Dropped())
+}
+
+func TestSubscribe_WhenBufferFull_DropsChanges(t *testing.T) {
+	sub := Subscribe(1)
+	t.Cleanup(func() { Unsubscribe(sub) })
+
+	changes := []ConditionChange{
+		{Current: metav1.Condition{Type: string(lokiv1.ConditionPending)}},
+		{Current: metav1.Condition{Type: string(lokiv1.ConditionReady)}},
+		{Current: metav1.Condition{Type: string(lokiv1.ConditionFailed)}},
+	}
+	publish(changes)
+
+	require.Len(t, sub.C(), 1)
+	require.Equal(t, uint64(2), sub.Dropped())
+	require.Equal(t, changes[0], <-sub.C())
+}
+
+func TestUnsubscribe_ClosesChannel(t *testing.T) {
+	sub := Subscribe(1)
+	Unsubscribe(sub)
+	// Unsubscribing twice is safe
+	Unsubscribe(sub)
+
+	publish([]ConditionChange{{Current: metav1.Condition{Type: string(lokiv1.ConditionReady)}}})
+
+	_, ok := <-sub.C()
+	require.False(t, ok)
+	require.Zero(t, sub.Dropped())
+}
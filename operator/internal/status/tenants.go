@@ -0,0 +1,47 @@
+package status
+
+import (
+	"context"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetTenantCondition updates or appends the condition to the status conditions of a single tenant.
+// In addition it resets all other conditions of the same tenant to false. Conditions of
+// other tenants and the LokiStack conditions are left untouched.
+func SetTenantCondition(
+	ctx context.Context,
+	k k8s.Client,
+	req ctrl.Request,
+	tenant string,
+	conditionType lokiv1.LokiStackConditionType,
+	msg string,
+	reason lokiv1.LokiStackConditionReason,
+) error {
+	condition := metav1.Condition{
+		Type:    string(conditionType),
+		Message: msg,
+		Reason:  string(reason),
+		Status:  metav1.ConditionTrue,
+	}
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		if hasActiveCondition(stack.Status.Tenants[tenant].Conditions, condition) {
+			// tenant already has desired condition
+			return false
+		}
+
+		if stack.Status.Tenants == nil {
+			stack.Status.Tenants = map[string]lokiv1.LokiStackTenantStatus{}
+		}
+
+		ts := stack.Status.Tenants[tenant]
+		ts.Conditions = MutualExclusionPolicy.apply(ts.Conditions, condition, metav1.NewTime(now()), nil)
+		stack.Status.Tenants[tenant] = ts
+		return true
+	})
+}
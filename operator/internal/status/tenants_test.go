@@ -0,0 +1,127 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetTenantCondition_WhenGetLokiStackReturnsNotFound_DoNothing(t *testing.T) {
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k := &k8sfakes.FakeClient{}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+
+	err := SetTenantCondition(context.Background(), k, r, "application", lokiv1.ConditionDegraded, "rate limited", lokiv1.ReasonInvalidTenantsConfiguration)
+	require.NoError(t, err)
+	require.Zero(t, k.StatusCallCount())
+}
+
+func TestSetTenantCondition_WhenExisting_DoNothing(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Tenants: map[string]lokiv1.LokiStackTenantStatus{
+				"application": {
+					Conditions: []metav1.Condition{
+						{
+							Type:    string(lokiv1.ConditionDegraded),
+							Reason:  string(lokiv1.ReasonInvalidTenantsConfiguration),
+							Message: "rate limited",
+							Status:  metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, _ := setupFakes(&s)
+
+	err := SetTenantCondition(context.Background(), k, r, "application", lokiv1.ConditionDegraded, "rate limited", lokiv1.ReasonInvalidTenantsConfiguration)
+	require.NoError(t, err)
+	require.Zero(t, k.StatusCallCount())
+}
+
+func TestSetTenantCondition_WhenNoneExisting_AppendTenantCondition(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionReady),
+					Reason:  string(lokiv1.ReasonReadyComponents),
+					Message: messageReady,
+					Status:  metav1.ConditionTrue,
+				},
+			},
+			Tenants: map[string]lokiv1.LokiStackTenantStatus{
+				"infrastructure": {
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(lokiv1.ConditionReady),
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		actual := obj.(*lokiv1.LokiStack)
+
+		tc := actual.Status.Tenants["application"].Conditions
+		require.Len(t, tc, 1)
+		require.Equal(t, string(lokiv1.ConditionDegraded), tc[0].Type)
+		require.Equal(t, metav1.ConditionTrue, tc[0].Status)
+
+		// Other tenants and the stack conditions are untouched
+		require.Equal(t, metav1.ConditionTrue, actual.Status.Tenants["infrastructure"].Conditions[0].Status)
+		require.Equal(t, metav1.ConditionTrue, actual.Status.Conditions[0].Status)
+		return nil
+	}
+
+	err := SetTenantCondition(context.Background(), k, r, "application", lokiv1.ConditionDegraded, "rate limited", lokiv1.ReasonInvalidTenantsConfiguration)
+	require.NoError(t, err)
+	require.NotZero(t, sw.UpdateCallCount())
+}
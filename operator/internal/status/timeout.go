@@ -0,0 +1,43 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ErrStatusWriteTimeout is returned by SetConditionWithTimeout if writing the status does not
+// complete within the given timeout.
+var ErrStatusWriteTimeout = errors.New("lokistack status write timed out")
+
+// SetConditionWithTimeout sets the condition to true like SetCondition, but bounds the lookup and
+// the write of the status, including retries, by the timeout. State conditions are set with
+// MutualExclusionPolicy and all other conditions, e.g. Warning, with CoexistPolicy. A write not
+// completing in time returns an error wrapping ErrStatusWriteTimeout. The status may still be
+// written by a request already sent to the apiserver.
+func SetConditionWithTimeout(ctx context.Context, k k8s.Client, req ctrl.Request, condition metav1.Condition, timeout time.Duration) error {
+	policy := CoexistPolicy
+	if isStateCondition(condition.Type) {
+		policy = MutualExclusionPolicy
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := updateCondition(tctx, k, req, condition, policy)
+	if err != nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return kverrors.Wrap(ErrStatusWriteTimeout, "failed to write LokiStack status within timeout",
+			"name", req.NamespacedName,
+			"timeout", timeout,
+			"error", err.Error(),
+		)
+	}
+
+	return err
+}
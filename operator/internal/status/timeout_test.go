@@ -0,0 +1,82 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetConditionWithTimeout(t *testing.T) {
+	table := []struct {
+		name        string
+		delay       time.Duration
+		wantTimeout bool
+	}{
+		{
+			name: "fast client",
+		},
+		{
+			name:        "slow client",
+			delay:       time.Minute,
+			wantTimeout: true,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				select {
+				case <-time.After(tc.delay):
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			condition := metav1.Condition{
+				Type:    string(lokiv1.ConditionWarning),
+				Reason:  string(lokiv1.ReasonWALDiskPressure),
+				Message: "some warning",
+			}
+
+			start := time.Now()
+			err := SetConditionWithTimeout(context.Background(), k, r, condition, 50*time.Millisecond)
+			require.Less(t, time.Since(start), 10*time.Second)
+			require.Equal(t, 1, sw.UpdateCallCount())
+
+			if !tc.wantTimeout {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrStatusWriteTimeout))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package status
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// TransitionSink persists the condition transitions of all LokiStacks for a later replay, e.g. to a
+// file, a ConfigMap or an external store. Append is called synchronously after every status write
+// of this package with the transitions in the order they were written. Implementations must be safe
+// for concurrent use if the controller reconciles LokiStacks concurrently.
+type TransitionSink interface {
+	// Append persists the transition. Errors are logged but never fail the status write.
+	Append(ctx context.Context, change ConditionChange) error
+}
+
+// NoopTransitionSink discards all transitions. It is the default TransitionSink.
+type NoopTransitionSink struct{}
+
+// Append implements TransitionSink.
+func (NoopTransitionSink) Append(context.Context, ConditionChange) error {
+	return nil
+}
+
+// sinkTransitions appends the changes to the configured TransitionSink in order.
+func sinkTransitions(ctx context.Context, changes []ConditionChange) {
+	sink := options.TransitionSink
+	if sink == nil {
+		return
+	}
+
+	for _, change := range changes {
+		if err := sink.Append(ctx, change); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to append condition transition to sink",
+				"lokistack", change.Stack.String(),
+				"type", change.Current.Type,
+			)
+		}
+	}
+}
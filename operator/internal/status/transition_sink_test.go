@@ -0,0 +1,94 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type memoryTransitionSink struct {
+	mu      sync.Mutex
+	changes []ConditionChange
+	err     error
+}
+
+func (m *memoryTransitionSink) Append(_ context.Context, change ConditionChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.changes = append(m.changes, change)
+	return m.err
+}
+
+func TestTransitionSink_ReceivesOrderedTransitions(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	sink := &memoryTransitionSink{}
+	opts := DefaultOptions()
+	opts.TransitionSink = sink
+	Configure(opts)
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	require.NoError(t, SetPendingCondition(context.Background(), k, r))
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+	// No-op writes are no transitions
+	require.NoError(t, SetReadyCondition(context.Background(), k, r))
+
+	// A failing sink never fails the status write
+	sink.err = errors.New("sink unavailable")
+	require.NoError(t, SetDegradedCondition(context.Background(), k, r, "some degraded", lokiv1.ReasonMissingObjectStorageSecret))
+
+	type transition struct {
+		Type     string
+		Status   metav1.ConditionStatus
+		Previous metav1.ConditionStatus
+	}
+
+	var got []transition
+	for _, c := range sink.changes {
+		require.Equal(t, r.NamespacedName, c.Stack)
+
+		tr := transition{Type: c.Current.Type, Status: c.Current.Status}
+		if c.Previous != nil {
+			tr.Previous = c.Previous.Status
+		}
+		got = append(got, tr)
+	}
+
+	require.Equal(t, []transition{
+		{Type: string(lokiv1.ConditionPending), Status: metav1.ConditionTrue},
+		{Type: string(lokiv1.ConditionPending), Status: metav1.ConditionFalse, Previous: metav1.ConditionTrue},
+		{Type: string(lokiv1.ConditionReady), Status: metav1.ConditionTrue},
+		{Type: string(lokiv1.ConditionReady), Status: metav1.ConditionFalse, Previous: metav1.ConditionTrue},
+		{Type: string(lokiv1.ConditionDegraded), Status: metav1.ConditionTrue},
+	}, got)
+}
@@ -0,0 +1,91 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// expiringConditionTypes defines the condition types cleared once older than Options.ConditionTTL.
+var expiringConditionTypes = map[string]struct{}{
+	string(lokiv1.ConditionWarning):  {},
+	string(lokiv1.ConditionDegraded): {},
+}
+
+// conditionAssertions records when a condition type was last asserted for a LokiStack.
+// Re-asserting an already active condition does not write the status and thus keeps
+// its LastTransitionTime, yet it must not expire.
+type conditionAssertions struct {
+	last map[string]time.Time
+}
+
+var assertions = newStackStore[conditionAssertions]()
+
+func recordAssertion(key types.NamespacedName, conditionType string, now time.Time) {
+	assertions.update(key, func(a *conditionAssertions) {
+		if a.last == nil {
+			a.last = map[string]time.Time{}
+		}
+		a.last[conditionType] = now
+	})
+}
+
+func lastAsserted(key types.NamespacedName, conditionType string) time.Time {
+	var t time.Time
+	assertions.read(key, func(a *conditionAssertions) {
+		t = a.last[conditionType]
+	})
+
+	return t
+}
+
+// ExpireStaleConditions sets active Warning and Degraded conditions to false if neither
+// their LastTransitionTime nor their last re-assertion by this operator is within the
+// configured Options.ConditionTTL. An expired Warning drops all listed Status.Warnings.
+// Pinned conditions never expire. A zero TTL disables expiry.
+func ExpireStaleConditions(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	ttl := options.ConditionTTL
+	if ttl == 0 {
+		return nil
+	}
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		current := now()
+
+		var changed bool
+		for i, c := range stack.Status.Conditions {
+			if _, ok := expiringConditionTypes[c.Type]; !ok || c.Status != metav1.ConditionTrue {
+				continue
+			}
+			if isPinned(stack, c.Type, c.Reason) {
+				continue
+			}
+
+			last := c.LastTransitionTime.Time
+			if asserted := lastAsserted(req.NamespacedName, c.Type); asserted.After(last) {
+				last = asserted
+			}
+
+			if current.Sub(last) <= ttl {
+				continue
+			}
+
+			stack.Status.Conditions[i].Status = metav1.ConditionFalse
+			stack.Status.Conditions[i].LastTransitionTime = metav1.NewTime(current)
+			changed = true
+
+			// expired warnings must not be listed again by the next warning
+			if c.Type == string(lokiv1.ConditionWarning) {
+				stack.Status.Warnings = nil
+			}
+		}
+
+		return changed
+	})
+}
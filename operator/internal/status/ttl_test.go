@@ -0,0 +1,135 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestExpireStaleConditions(t *testing.T) {
+	opts := DefaultOptions()
+	opts.ConditionTTL = time.Hour
+	Configure(opts)
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	table := []struct {
+		name       string
+		age        time.Duration
+		reasserted bool
+		wantStatus metav1.ConditionStatus
+	}{
+		{
+			name:       "fresh warning",
+			age:        time.Minute,
+			wantStatus: metav1.ConditionTrue,
+		},
+		{
+			name:       "stale warning",
+			age:        2 * time.Hour,
+			wantStatus: metav1.ConditionFalse,
+		},
+		{
+			name:       "stale but re-asserted warning",
+			age:        2 * time.Hour,
+			reasserted: true,
+			wantStatus: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			warning := metav1.Condition{
+				Type:               string(lokiv1.ConditionWarning),
+				Reason:             string(lokiv1.ReasonWALDiskPressure),
+				Message:            "Write ahead log disk of component ingester is 85% used",
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-tc.age)),
+			}
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: []metav1.Condition{warning},
+					Warnings:   listedWarnings("ingester", warning),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+			t.Cleanup(func() { assertions.delete(r.NamespacedName) })
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				actual := obj.(*lokiv1.LokiStack)
+				require.Equal(t, tc.wantStatus, actual.Status.Conditions[0].Status)
+				require.Empty(t, actual.Status.Warnings)
+				return nil
+			}
+
+			if tc.reasserted {
+				err := SetWALPressureCondition(context.Background(), k, r, "ingester", 85)
+				require.NoError(t, err)
+				require.Zero(t, sw.UpdateCallCount())
+			}
+
+			err := ExpireStaleConditions(context.Background(), k, r)
+			require.NoError(t, err)
+
+			if tc.wantStatus == metav1.ConditionFalse {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestExpireStaleConditions_WhenTTLDisabled_DoNothing(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(lokiv1.ConditionDegraded),
+					Reason:             string(lokiv1.ReasonMissingObjectStorageSecret),
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-24 * time.Hour)),
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+
+	err := ExpireStaleConditions(context.Background(), k, r)
+	require.NoError(t, err)
+	require.Zero(t, k.GetCallCount())
+	require.Zero(t, sw.UpdateCallCount())
+}
@@ -0,0 +1,95 @@
+package status
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const messageUnknown = "Status unknown, the operator shut down"
+
+// MarkUnknownForAll sets the active conditions of the types returned by ManagedConditionTypes to
+// Unknown on all LokiStacks across all namespaces matching the selector, e.g. on a graceful
+// shutdown after losing the leader election, so that the next leader does not act on a stale
+// Ready. The reasons are kept and LokiStacks not owned by this operator are skipped. The
+// LokiStacks are listed and written one by one, bounded by the configured ShutdownTimeout.
+// It returns the errors per LokiStack, including LokiStacks not written before the timeout
+// elapsed, and an error if the LokiStacks cannot be listed.
+func MarkUnknownForAll(ctx context.Context, k k8s.Client, selector labels.Selector) (map[types.NamespacedName]error, error) {
+	if options.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.ShutdownTimeout)
+		defer cancel()
+	}
+
+	var (
+		errs  = map[types.NamespacedName]error{}
+		token string
+	)
+
+	for {
+		var list lokiv1.LokiStackList
+		opts := []client.ListOption{
+			client.MatchingLabelsSelector{Selector: selector},
+			client.Limit(listPageSize),
+		}
+		if token != "" {
+			opts = append(opts, client.Continue(token))
+		}
+
+		if err := k.List(ctx, &list, opts...); err != nil {
+			return errs, kverrors.Wrap(err, "failed to list LokiStacks", "selector", selector.String())
+		}
+
+		for _, s := range list.Items {
+			key := client.ObjectKeyFromObject(&s)
+			if err := ctx.Err(); err != nil {
+				errs[key] = kverrors.Wrap(ErrStatusWriteTimeout, "failed to mark LokiStack conditions unknown", "name", key, "error", err.Error())
+				continue
+			}
+
+			err := markUnknown(ctx, k, ctrl.Request{NamespacedName: key})
+			if err != nil && !errors.Is(err, ErrNotOwned) {
+				errs[key] = err
+			}
+		}
+
+		token = list.Continue
+		if token == "" {
+			return errs, nil
+		}
+	}
+}
+
+// markUnknown sets the active managed conditions of the LokiStack to Unknown.
+func markUnknown(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	managed := map[string]struct{}{}
+	for _, t := range ManagedConditionTypes() {
+		managed[t] = struct{}{}
+	}
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		var changed bool
+		for i, c := range stack.Status.Conditions {
+			if _, ok := managed[c.Type]; !ok || c.Status != metav1.ConditionTrue {
+				continue
+			}
+
+			stack.Status.Conditions[i].Status = metav1.ConditionUnknown
+			stack.Status.Conditions[i].Message = messageUnknown
+			stack.Status.Conditions[i].LastTransitionTime = metav1.NewTime(now())
+			changed = true
+		}
+
+		return changed
+	})
+}
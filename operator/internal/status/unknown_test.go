@@ -0,0 +1,159 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func setupStackFleet(names ...string) (*k8sfakes.FakeClient, *k8sfakes.FakeStatusWriter, map[string]*lokiv1.LokiStack) {
+	stacks := map[string]*lokiv1.LokiStack{}
+	var items []lokiv1.LokiStack
+	for _, name := range names {
+		s := lokiv1.LokiStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "some-ns",
+			},
+			Status: lokiv1.LokiStackStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    string(lokiv1.ConditionReady),
+						Reason:  string(lokiv1.ReasonReadyComponents),
+						Message: messageReady,
+						Status:  metav1.ConditionTrue,
+					},
+					{
+						Type:    string(lokiv1.ConditionWarning),
+						Reason:  string(lokiv1.ReasonWALDiskPressure),
+						Message: "some warning",
+						Status:  metav1.ConditionTrue,
+					},
+					{
+						Type:   string(lokiv1.ConditionDegraded),
+						Reason: string(lokiv1.ReasonMissingObjectStorageSecret),
+						Status: metav1.ConditionFalse,
+					},
+					{
+						Type:   "external.example.com/Custom",
+						Reason: "Custom",
+						Status: metav1.ConditionTrue,
+					},
+				},
+			},
+		}
+		stacks[name] = &s
+		items = append(items, *s.DeepCopy())
+	}
+
+	sw := &k8sfakes.FakeStatusWriter{}
+	k := &k8sfakes.FakeClient{}
+	k.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		k.SetClientObjectList(list, &lokiv1.LokiStackList{Items: items})
+		return nil
+	}
+	k.GetStub = func(_ context.Context, name types.NamespacedName, object client.Object, _ ...client.GetOption) error {
+		if s, ok := stacks[name.Name]; ok {
+			k.SetClientObject(object, s.DeepCopy())
+			return nil
+		}
+		return apierrors.NewNotFound(schema.GroupResource{}, "something wasn't found")
+	}
+	k.StatusStub = func() client.StatusWriter { return sw }
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(stacks[obj.GetName()])
+		return nil
+	}
+
+	return k, sw, stacks
+}
+
+func TestMarkUnknownForAll(t *testing.T) {
+	k, sw, stacks := setupStackFleet("stack-a", "stack-b", "stack-c")
+
+	update := sw.UpdateStub
+	sw.UpdateStub = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+		if obj.GetName() == "stack-b" {
+			return apierrors.NewBadRequest("something went wrong")
+		}
+		return update(ctx, obj, opts...)
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "loki"})
+	errs, err := MarkUnknownForAll(context.Background(), k, selector)
+	require.NoError(t, err)
+
+	_, _, opts := k.ListArgsForCall(0)
+	require.Contains(t, opts, client.MatchingLabelsSelector{Selector: selector})
+
+	require.Len(t, errs, 1)
+	require.Error(t, errs[types.NamespacedName{Name: "stack-b", Namespace: "some-ns"}])
+
+	for _, name := range []string{"stack-a", "stack-c"} {
+		conditions := ConditionsMap(stacks[name])
+		for _, typ := range []lokiv1.LokiStackConditionType{lokiv1.ConditionReady, lokiv1.ConditionWarning} {
+			c := conditions[string(typ)]
+			require.Equal(t, metav1.ConditionUnknown, c.Status, name)
+			require.Equal(t, messageUnknown, c.Message, name)
+		}
+		require.Equal(t, string(lokiv1.ReasonReadyComponents), conditions[string(lokiv1.ConditionReady)].Reason)
+		require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionDegraded)].Status)
+		require.Equal(t, metav1.ConditionTrue, conditions["external.example.com/Custom"].Status)
+	}
+
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(stacks["stack-b"])[string(lokiv1.ConditionReady)].Status)
+
+	// Marking again is a no-op
+	calls := sw.UpdateCallCount()
+	sw.UpdateStub = update
+	errs, err = MarkUnknownForAll(context.Background(), k, selector)
+	require.NoError(t, err)
+	require.Empty(t, errs)
+	require.Equal(t, calls+1, sw.UpdateCallCount())
+}
+
+func TestMarkUnknownForAll_RespectsTimeout(t *testing.T) {
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	opts := DefaultOptions()
+	opts.ShutdownTimeout = 10 * time.Millisecond
+	Configure(opts)
+
+	k, sw, stacks := setupStackFleet("stack-a", "stack-b")
+
+	update := sw.UpdateStub
+	sw.UpdateStub = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+		<-ctx.Done()
+		return update(ctx, obj, opts...)
+	}
+
+	errs, err := MarkUnknownForAll(context.Background(), k, labels.Everything())
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[types.NamespacedName{Name: "stack-b", Namespace: "some-ns"}], ErrStatusWriteTimeout)
+
+	require.Equal(t, metav1.ConditionUnknown, ConditionsMap(stacks["stack-a"])[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionTrue, ConditionsMap(stacks["stack-b"])[string(lokiv1.ConditionReady)].Status)
+}
+
+func TestMarkUnknownForAll_WhenListReturnsError_ReturnError(t *testing.T) {
+	k := &k8sfakes.FakeClient{}
+	k.ListStub = func(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+		return apierrors.NewBadRequest("something went wrong")
+	}
+
+	_, err := MarkUnknownForAll(context.Background(), k, labels.Everything())
+	require.Error(t, err)
+}
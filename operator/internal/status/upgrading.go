@@ -0,0 +1,34 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetUpgradingCondition sets the condition Upgrading alongside the other conditions while the
+// LokiStack is upgraded from fromVersion to toVersion, e.g. with a progress of "2/3 components".
+// The condition coexists with the state conditions, i.e. a Degraded read or write path during
+// the rollout is still reported. Once the upgrade completed, i.e. fromVersion is empty or equal
+// to toVersion, the condition is cleared.
+func SetUpgradingCondition(ctx context.Context, k k8s.Client, req ctrl.Request, fromVersion, toVersion, progress string) error {
+	if fromVersion == "" || fromVersion == toVersion {
+		return clearCondition(ctx, k, req, lokiv1.ConditionUpgrading, lokiv1.ReasonUpgradeInProgress)
+	}
+
+	msg := fmt.Sprintf("Upgrading from %s to %s", fromVersion, toVersion)
+	if progress != "" {
+		msg = fmt.Sprintf("%s: %s", msg, progress)
+	}
+
+	return updateCondition(ctx, k, req, metav1.Condition{
+		Type:    string(lokiv1.ConditionUpgrading),
+		Message: msg,
+		Reason:  string(lokiv1.ReasonUpgradeInProgress),
+	}, CoexistPolicy)
+}
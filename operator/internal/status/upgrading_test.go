@@ -0,0 +1,85 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetUpgradingCondition(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionDegraded),
+					Reason:  string(lokiv1.ReasonFailedComponents),
+					Message: "some degraded",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	requireUpgrading := func(status metav1.ConditionStatus, msg string) {
+		t.Helper()
+
+		conditions := ConditionsMap(&s)
+		c := conditions[string(lokiv1.ConditionUpgrading)]
+		require.Equal(t, status, c.Status)
+		require.Equal(t, string(lokiv1.ReasonUpgradeInProgress), c.Reason)
+		require.Equal(t, msg, c.Message)
+
+		// The degraded write path is still reported during the upgrade
+		require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionDegraded)].Status)
+	}
+
+	// In progress
+	err := SetUpgradingCondition(context.Background(), k, r, "v2.6.1", "v2.7.0", "1/3 components")
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+	requireUpgrading(metav1.ConditionTrue, "Upgrading from v2.6.1 to v2.7.0: 1/3 components")
+
+	err = SetUpgradingCondition(context.Background(), k, r, "v2.6.1", "v2.7.0", "1/3 components")
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+
+	err = SetUpgradingCondition(context.Background(), k, r, "v2.6.1", "v2.7.0", "2/3 components")
+	require.NoError(t, err)
+	require.Equal(t, 2, sw.UpdateCallCount())
+	requireUpgrading(metav1.ConditionTrue, "Upgrading from v2.6.1 to v2.7.0: 2/3 components")
+
+	// Completed
+	err = SetUpgradingCondition(context.Background(), k, r, "v2.7.0", "v2.7.0", "")
+	require.NoError(t, err)
+	require.Equal(t, 3, sw.UpdateCallCount())
+	requireUpgrading(metav1.ConditionFalse, "Upgrading from v2.6.1 to v2.7.0: 2/3 components")
+
+	err = SetUpgradingCondition(context.Background(), k, r, "", "v2.7.0", "")
+	require.NoError(t, err)
+	require.Equal(t, 3, sw.UpdateCallCount())
+}
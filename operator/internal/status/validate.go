@@ -0,0 +1,92 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidateStatus checks the invariants of the LokiStack status conditions and returns an error
+// listing all violations. The conditions must have unique types, a valid status and a transition
+// time. With MutualExclusionPolicy at most one of the managed conditions describing the state of
+// the LokiStack is true, i.e. all managed conditions except Warning which is always reported
+// alongside the state. The LokiStack is never mutated.
+func ValidateStatus(stack *lokiv1.LokiStack, policy ConditionPolicy) error {
+	var (
+		violations []string
+		seen       = map[string]struct{}{}
+		active     []string
+	)
+
+	for _, c := range stack.Status.Conditions {
+		if _, ok := seen[c.Type]; ok {
+			violations = append(violations, fmt.Sprintf("duplicate condition type %s", c.Type))
+		}
+		seen[c.Type] = struct{}{}
+
+		switch c.Status {
+		case metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+		default:
+			violations = append(violations, fmt.Sprintf("condition %s has invalid status %q", c.Type, c.Status))
+		}
+
+		if c.LastTransitionTime.IsZero() {
+			violations = append(violations, fmt.Sprintf("condition %s has no transition time", c.Type))
+		}
+
+		if c.Status == metav1.ConditionTrue && isStateCondition(c.Type) {
+			active = append(active, c.Type)
+		}
+	}
+
+	if policy == MutualExclusionPolicy && len(active) > 1 {
+		sort.Strings(active)
+		violations = append(violations, fmt.Sprintf("mutually exclusive conditions %v are true", active))
+	}
+
+	if len(violations) > 0 {
+		return kverrors.New("invalid lokistack status", "name", stack.Name, "violations", violations)
+	}
+
+	return nil
+}
+
+// AssertReadyInvariant returns an error if the condition Ready is true while the condition
+// Degraded or Failed is true as well, e.g. after a logic bug writing conditions with
+// CoexistPolicy. Unlike ValidateStatus it checks this invariant only. The LokiStack is never
+// mutated.
+func AssertReadyInvariant(stack *lokiv1.LokiStack) error {
+	var (
+		ready     bool
+		conflicts []string
+	)
+
+	for _, c := range stack.Status.Conditions {
+		if c.Status != metav1.ConditionTrue {
+			continue
+		}
+
+		switch lokiv1.LokiStackConditionType(c.Type) {
+		case lokiv1.ConditionReady:
+			ready = true
+		case lokiv1.ConditionDegraded, lokiv1.ConditionFailed:
+			conflicts = append(conflicts, c.Type)
+		}
+	}
+
+	if !ready || len(conflicts) == 0 {
+		return nil
+	}
+
+	sort.Strings(conflicts)
+	return kverrors.New("lokistack ready while degraded or failed", "name", stack.Name, "conditions", conflicts)
+}
+
+// isStateCondition returns true for the managed conditions of which at most one is true.
+func isStateCondition(conditionType string) bool {
+	return isManagedCondition(conditionType) && conditionType != string(lokiv1.ConditionWarning)
+}
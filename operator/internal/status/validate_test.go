@@ -0,0 +1,194 @@
+package status_test
+
+import (
+	"testing"
+	"time"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/status"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateStatus(t *testing.T) {
+	ts := metav1.NewTime(time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC))
+	condition := func(conditionType lokiv1.LokiStackConditionType, status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(conditionType),
+			Reason:             "SomeReason",
+			Status:             status,
+			LastTransitionTime: ts,
+		}
+	}
+
+	table := []struct {
+		name       string
+		policy     status.ConditionPolicy
+		conditions []metav1.Condition
+		wantErr    bool
+	}{
+		{
+			name:   "no conditions",
+			policy: status.MutualExclusionPolicy,
+		},
+		{
+			name:   "single state with warning",
+			policy: status.MutualExclusionPolicy,
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionPending, metav1.ConditionFalse),
+				condition(lokiv1.ConditionWarning, metav1.ConditionTrue),
+				condition(lokiv1.ConditionReadOnly, metav1.ConditionTrue),
+			},
+		},
+		{
+			name:   "duplicate type",
+			policy: status.CoexistPolicy,
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionReady, metav1.ConditionFalse),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "invalid status",
+			policy: status.CoexistPolicy,
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, "Maybe"),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "missing transition time",
+			policy: status.CoexistPolicy,
+			conditions: []metav1.Condition{
+				{
+					Type:   string(lokiv1.ConditionReady),
+					Reason: string(lokiv1.ReasonReadyComponents),
+					Status: metav1.ConditionTrue,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "multiple states with mutual exclusion",
+			policy: status.MutualExclusionPolicy,
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionDegraded, metav1.ConditionTrue),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "multiple states with coexist",
+			policy: status.CoexistPolicy,
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionDegraded, metav1.ConditionTrue),
+			},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stack := &lokiv1.LokiStack{
+				Status: lokiv1.LokiStackStatus{Conditions: tc.conditions},
+			}
+			before := stack.DeepCopy()
+
+			err := status.ValidateStatus(stack, tc.policy)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, before, stack)
+		})
+	}
+}
+
+func TestAssertReadyInvariant(t *testing.T) {
+	condition := func(conditionType lokiv1.LokiStackConditionType, status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{
+			Type:   string(conditionType),
+			Reason: "SomeReason",
+			Status: status,
+		}
+	}
+
+	table := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantErr    bool
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name: "ready with warning",
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionWarning, metav1.ConditionTrue),
+				condition(lokiv1.ConditionDegraded, metav1.ConditionFalse),
+			},
+		},
+		{
+			name: "degraded and failed while not ready",
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionFalse),
+				condition(lokiv1.ConditionDegraded, metav1.ConditionTrue),
+				condition(lokiv1.ConditionFailed, metav1.ConditionTrue),
+			},
+		},
+		{
+			name: "ready while degraded",
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionDegraded, metav1.ConditionTrue),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ready while failed",
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionFailed, metav1.ConditionTrue),
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ready while unknown failed",
+			conditions: []metav1.Condition{
+				condition(lokiv1.ConditionReady, metav1.ConditionTrue),
+				condition(lokiv1.ConditionFailed, metav1.ConditionUnknown),
+			},
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			stack := &lokiv1.LokiStack{
+				Status: lokiv1.LokiStackStatus{
+					Conditions: tc.conditions,
+				},
+			}
+			before := stack.DeepCopy()
+
+			err := status.AssertReadyInvariant(stack)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, before, stack)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetWALPressureCondition reports the disk usage of the write ahead log of a component.
+// Usage above the configured warning threshold sets the condition Warning, usage above
+// the degraded threshold sets the condition Degraded. Usage below both thresholds
+// clears a previously reported WAL disk pressure warning of the same component.
+func SetWALPressureCondition(ctx context.Context, k k8s.Client, req ctrl.Request, component string, percentUsed float64) error {
+	msg := fmt.Sprintf("Write ahead log disk of component %s is %.0f%% used", component, percentUsed)
+
+	switch {
+	case percentUsed >= options.WALPressure.Degraded:
+		return SetDegradedCondition(ctx, k, req, msg, lokiv1.ReasonWALDiskPressure)
+	case percentUsed >= options.WALPressure.Warning:
+		return setWarning(ctx, k, req, lokiv1.LokiStackWarning{
+			Reason:    lokiv1.ReasonWALDiskPressure,
+			Component: component,
+			Message:   msg,
+		})
+	default:
+		return clearWarning(ctx, k, req, lokiv1.ReasonWALDiskPressure, component)
+	}
+}
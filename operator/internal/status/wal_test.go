@@ -0,0 +1,170 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetWALPressureCondition(t *testing.T) {
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	walWarning := metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Reason:  string(lokiv1.ReasonWALDiskPressure),
+		Message: "Write ahead log disk of component ingester is 85% used",
+		Status:  metav1.ConditionTrue,
+	}
+
+	table := []struct {
+		name        string
+		percentUsed float64
+		conditions  []metav1.Condition
+		wantUpdate  bool
+		wantType    lokiv1.LokiStackConditionType
+		wantStatus  metav1.ConditionStatus
+		wantReady   metav1.ConditionStatus
+	}{
+		{
+			name:        "below warning threshold",
+			percentUsed: 79.9,
+			conditions:  []metav1.Condition{ready},
+		},
+		{
+			name:        "below warning threshold clears warning",
+			percentUsed: 50,
+			conditions:  []metav1.Condition{ready, walWarning},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionWarning,
+			wantStatus:  metav1.ConditionFalse,
+			wantReady:   metav1.ConditionTrue,
+		},
+		{
+			name:        "at warning threshold",
+			percentUsed: 80,
+			conditions:  []metav1.Condition{ready},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionWarning,
+			wantStatus:  metav1.ConditionTrue,
+			wantReady:   metav1.ConditionTrue,
+		},
+		{
+			name:        "below degraded threshold",
+			percentUsed: 94.9,
+			conditions:  []metav1.Condition{ready},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionWarning,
+			wantStatus:  metav1.ConditionTrue,
+			wantReady:   metav1.ConditionTrue,
+		},
+		{
+			name:        "at degraded threshold",
+			percentUsed: 95,
+			conditions:  []metav1.Condition{ready, walWarning},
+			wantUpdate:  true,
+			wantType:    lokiv1.ConditionDegraded,
+			wantStatus:  metav1.ConditionTrue,
+			wantReady:   metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: append([]metav1.Condition{}, tc.conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				actual := obj.(*lokiv1.LokiStack)
+
+				var found bool
+				for _, c := range actual.Status.Conditions {
+					switch c.Type {
+					case string(tc.wantType):
+						found = true
+						require.Equal(t, tc.wantStatus, c.Status)
+						require.Equal(t, string(lokiv1.ReasonWALDiskPressure), c.Reason)
+					case string(lokiv1.ConditionReady):
+						require.Equal(t, tc.wantReady, c.Status)
+					}
+				}
+				require.True(t, found)
+				return nil
+			}
+
+			err := SetWALPressureCondition(context.Background(), k, r, "ingester", tc.percentUsed)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
+
+func TestSetWALPressureCondition_UsesConfiguredThresholds(t *testing.T) {
+	Configure(Options{
+		WALPressure: Thresholds{
+			Warning:  50,
+			Degraded: 60,
+		},
+	})
+	t.Cleanup(func() { Configure(DefaultOptions()) })
+
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		actual := obj.(*lokiv1.LokiStack)
+		require.Len(t, actual.Status.Conditions, 1)
+		require.Equal(t, string(lokiv1.ConditionDegraded), actual.Status.Conditions[0].Type)
+		require.Equal(t, "Write ahead log disk of component ingester is 65% used", actual.Status.Conditions[0].Message)
+		return nil
+	}
+
+	err := SetWALPressureCondition(context.Background(), k, r, "ingester", 65)
+	require.NoError(t, err)
+	require.Equal(t, 1, sw.UpdateCallCount())
+}
@@ -0,0 +1,140 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const messageMultipleWarnings = "Multiple warnings"
+
+// setWarning adds the warning to Status.Warnings, replacing an earlier warning of the
+// same reason and component, and sets the condition Warning summarizing all of them.
+// Thus a warning reported for one concern never drops the warning of another one.
+func setWarning(ctx context.Context, k k8s.Client, req ctrl.Request, warning lokiv1.LokiStackWarning) error {
+	recordAssertion(req.NamespacedName, string(lokiv1.ConditionWarning), now())
+
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		var changed bool
+		if i := warningIndex(stack.Status.Warnings, warning.Reason, warning.Component); i >= 0 {
+			changed = stack.Status.Warnings[i] != warning
+			stack.Status.Warnings[i] = warning
+		} else {
+			stack.Status.Warnings = append(stack.Status.Warnings, warning)
+			sortWarnings(stack.Status.Warnings)
+			changed = true
+		}
+
+		return setCondition(lokiStackConditions(stack), warningCondition(stack.Status.Warnings), CoexistPolicy, metav1.NewTime(now()), nil) || changed
+	})
+}
+
+// clearWarning removes the warning of the given reason and component from Status.Warnings
+// unless it is pinned. The condition Warning is updated to summarize the remaining warnings
+// or is set to false if none is left. A condition Warning of the given reason without a
+// listed warning, e.g. set by an earlier operator version, is set to false as well.
+func clearWarning(ctx context.Context, k k8s.Client, req ctrl.Request, reason lokiv1.LokiStackConditionReason, component string) error {
+	return updateStatus(ctx, k, req, func(stack *lokiv1.LokiStack) bool {
+		if isPinned(stack, string(lokiv1.ConditionWarning), string(reason)) {
+			return false
+		}
+
+		i := warningIndex(stack.Status.Warnings, reason, component)
+		if i >= 0 {
+			stack.Status.Warnings = append(stack.Status.Warnings[:i], stack.Status.Warnings[i+1:]...)
+			if len(stack.Status.Warnings) == 0 {
+				stack.Status.Warnings = nil
+			}
+		}
+
+		if len(stack.Status.Warnings) > 0 {
+			setCondition(lokiStackConditions(stack), warningCondition(stack.Status.Warnings), CoexistPolicy, metav1.NewTime(now()), nil)
+			return i >= 0
+		}
+
+		for j, c := range stack.Status.Conditions {
+			if c.Type != string(lokiv1.ConditionWarning) || c.Status != metav1.ConditionTrue {
+				continue
+			}
+			if c.Reason != string(reason) && (i < 0 || c.Reason != string(lokiv1.ReasonMultipleWarnings)) {
+				continue
+			}
+
+			stack.Status.Conditions[j].Status = metav1.ConditionFalse
+			stack.Status.Conditions[j].LastTransitionTime = metav1.NewTime(now())
+			return true
+		}
+
+		return i >= 0
+	})
+}
+
+// pruneWarnings drops Status.Warnings if the condition Warning is not active anymore, e.g.
+// after being reset by the MutualExclusionPolicy, such that a later clearWarning does not
+// summarize stale warnings into a new condition Warning.
+func pruneWarnings(stack *lokiv1.LokiStack) bool {
+	if len(stack.Status.Warnings) == 0 {
+		return false
+	}
+
+	for _, c := range stack.Status.Conditions {
+		if c.Type == string(lokiv1.ConditionWarning) && c.Status == metav1.ConditionTrue {
+			return false
+		}
+	}
+
+	stack.Status.Warnings = nil
+	return true
+}
+
+// warningCondition returns the condition Warning summarizing the non-empty warnings. A single
+// warning is reported with its own reason and message, multiple warnings are reported with the
+// reason MultipleWarnings listing all messages.
+func warningCondition(warnings []lokiv1.LokiStackWarning) metav1.Condition {
+	if len(warnings) == 1 {
+		return metav1.Condition{
+			Type:    string(lokiv1.ConditionWarning),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(warnings[0].Reason),
+			Message: warnings[0].Message,
+		}
+	}
+
+	messages := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		messages = append(messages, w.Message)
+	}
+
+	return metav1.Condition{
+		Type:    string(lokiv1.ConditionWarning),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(lokiv1.ReasonMultipleWarnings),
+		Message: fmt.Sprintf("%s: %s", messageMultipleWarnings, strings.Join(messages, "; ")),
+	}
+}
+
+func warningIndex(warnings []lokiv1.LokiStackWarning, reason lokiv1.LokiStackConditionReason, component string) int {
+	for i, w := range warnings {
+		if w.Reason == reason && w.Component == component {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func sortWarnings(warnings []lokiv1.LokiStackWarning) {
+	sort.Slice(warnings, func(i, j int) bool {
+		if warnings[i].Reason != warnings[j].Reason {
+			return warnings[i].Reason < warnings[j].Reason
+		}
+		return warnings[i].Component < warnings[j].Component
+	})
+}
@@ -0,0 +1,383 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s/k8sfakes"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWarnings_KeepWarningsOfOtherConcerns(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	ctx := context.Background()
+	require.NoError(t, SetCacheCondition(ctx, k, r, false, "connection refused"))
+	require.NoError(t, SetAutoscalingCondition(ctx, k, r, true, "querier"))
+	require.NoError(t, SetAutoscalingCondition(ctx, k, r, true, "distributor"))
+
+	warning := ConditionsMap(&s)[string(lokiv1.ConditionWarning)]
+	require.Equal(t, metav1.ConditionTrue, warning.Status)
+	require.Equal(t, string(lokiv1.ReasonMultipleWarnings), warning.Reason)
+	require.Equal(t, "Multiple warnings: "+
+		"Autoscaler of component distributor is pinned at its maximum replicas; "+
+		"Autoscaler of component querier is pinned at its maximum replicas; "+
+		"Cache unreachable, queries may be slower: connection refused", warning.Message)
+	require.Len(t, s.Status.Warnings, 3)
+
+	require.NoError(t, SetAutoscalingCondition(ctx, k, r, false, "distributor"))
+	require.NoError(t, SetCacheCondition(ctx, k, r, true, ""))
+
+	warning = ConditionsMap(&s)[string(lokiv1.ConditionWarning)]
+	require.Equal(t, metav1.ConditionTrue, warning.Status)
+	require.Equal(t, string(lokiv1.ReasonAutoscalingAtMaxReplicas), warning.Reason)
+	require.Equal(t, "Autoscaler of component querier is pinned at its maximum replicas", warning.Message)
+	require.Equal(t, []lokiv1.LokiStackWarning{
+		{
+			Reason:    lokiv1.ReasonAutoscalingAtMaxReplicas,
+			Component: "querier",
+			Message:   "Autoscaler of component querier is pinned at its maximum replicas",
+		},
+	}, s.Status.Warnings)
+
+	require.NoError(t, SetAutoscalingCondition(ctx, k, r, false, "querier"))
+
+	warning = ConditionsMap(&s)[string(lokiv1.ConditionWarning)]
+	require.Equal(t, metav1.ConditionFalse, warning.Status)
+	require.Empty(t, s.Status.Warnings)
+	require.Equal(t, 6, sw.UpdateCallCount())
+}
+
+func TestClearWarning_WhenNotListed_ClearConditionOfSameReason(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+		Status: lokiv1.LokiStackStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(lokiv1.ConditionWarning),
+					Reason:  string(lokiv1.ReasonCacheUnreachable),
+					Message: "Cache unreachable, queries may be slower: connection refused",
+					Status:  metav1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	ctx := context.Background()
+	require.NoError(t, SetStorageCanaryCondition(ctx, k, r, true, ""))
+	require.Zero(t, sw.UpdateCallCount())
+
+	require.NoError(t, SetCacheCondition(ctx, k, r, true, ""))
+	require.Equal(t, 1, sw.UpdateCallCount())
+	require.Equal(t, metav1.ConditionFalse, ConditionsMap(&s)[string(lokiv1.ConditionWarning)].Status)
+}
+
+func TestClearWarning_WhenResetByReady_KeepWarningFalse(t *testing.T) {
+	s := lokiv1.LokiStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	r := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "my-stack",
+			Namespace: "some-ns",
+		},
+	}
+
+	k, sw := setupFakes(&s)
+	sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+		return nil
+	}
+
+	ctx := context.Background()
+	require.NoError(t, SetCacheCondition(ctx, k, r, false, "connection refused"))
+	require.NoError(t, SetAutoscalingCondition(ctx, k, r, true, "querier"))
+	require.Len(t, s.Status.Warnings, 2)
+
+	require.NoError(t, SetReadyCondition(ctx, k, r))
+	require.Equal(t, metav1.ConditionFalse, ConditionsMap(&s)[string(lokiv1.ConditionWarning)].Status)
+	require.Empty(t, s.Status.Warnings)
+
+	require.NoError(t, SetCacheCondition(ctx, k, r, true, ""))
+
+	conditions := ConditionsMap(&s)
+	require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+	require.Equal(t, metav1.ConditionFalse, conditions[string(lokiv1.ConditionWarning)].Status)
+	require.Equal(t, 3, sw.UpdateCallCount())
+}
+
+func TestSetWarningConditions(t *testing.T) {
+	ready := metav1.Condition{
+		Type:    string(lokiv1.ConditionReady),
+		Reason:  string(lokiv1.ReasonReadyComponents),
+		Message: messageReady,
+		Status:  metav1.ConditionTrue,
+	}
+
+	warning := func(reason lokiv1.LokiStackConditionReason, msg string) metav1.Condition {
+		return metav1.Condition{
+			Type:    string(lokiv1.ConditionWarning),
+			Reason:  string(reason),
+			Message: msg,
+			Status:  metav1.ConditionTrue,
+		}
+	}
+
+	var (
+		unreachable = warning(lokiv1.ReasonCacheUnreachable, "Cache unreachable, queries may be slower: dial tcp 10.0.0.12:11211: connect: connection refused")
+		denied      = warning(lokiv1.ReasonObjectStorageCanaryFailed, "Object storage write-then-read canary failed: PutObject: AccessDenied: Access Denied")
+		rejected    = warning(lokiv1.ReasonGatewayRouteNotAdmitted, "Gateway route for host loki.apps.example.com is not admitted")
+		atMax       = warning(lokiv1.ReasonAutoscalingAtMaxReplicas, "Autoscaler of component querier is pinned at its maximum replicas")
+	)
+
+	cache := func(healthy bool) func(context.Context, *k8sfakes.FakeClient, ctrl.Request) error {
+		return func(ctx context.Context, k *k8sfakes.FakeClient, r ctrl.Request) error {
+			detail := ""
+			if !healthy {
+				detail = "dial tcp 10.0.0.12:11211: connect: connection refused"
+			}
+			return SetCacheCondition(ctx, k, r, healthy, detail)
+		}
+	}
+	canary := func(ok bool) func(context.Context, *k8sfakes.FakeClient, ctrl.Request) error {
+		return func(ctx context.Context, k *k8sfakes.FakeClient, r ctrl.Request) error {
+			detail := ""
+			if !ok {
+				detail = "PutObject: AccessDenied: Access Denied"
+			}
+			return SetStorageCanaryCondition(ctx, k, r, ok, detail)
+		}
+	}
+	route := func(admitted bool) func(context.Context, *k8sfakes.FakeClient, ctrl.Request) error {
+		return func(ctx context.Context, k *k8sfakes.FakeClient, r ctrl.Request) error {
+			return SetRouteCondition(ctx, k, r, admitted, "loki.apps.example.com")
+		}
+	}
+	autoscaling := func(atMax bool, component string) func(context.Context, *k8sfakes.FakeClient, ctrl.Request) error {
+		return func(ctx context.Context, k *k8sfakes.FakeClient, r ctrl.Request) error {
+			return SetAutoscalingCondition(ctx, k, r, atMax, component)
+		}
+	}
+
+	table := []struct {
+		name        string
+		set         func(context.Context, *k8sfakes.FakeClient, ctrl.Request) error
+		component   string
+		warning     metav1.Condition
+		active      bool
+		wantUpdate  bool
+		wantWarning metav1.ConditionStatus
+	}{
+		{
+			name:    "cache healthy",
+			set:     cache(true),
+			warning: unreachable,
+		},
+		{
+			name:        "cache unreachable",
+			set:         cache(false),
+			warning:     unreachable,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionTrue,
+		},
+		{
+			name:    "cache still unreachable",
+			set:     cache(false),
+			warning: unreachable,
+			active:  true,
+		},
+		{
+			name:        "cache healthy after unreachable",
+			set:         cache(true),
+			warning:     unreachable,
+			active:      true,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionFalse,
+		},
+		{
+			name:    "canary pass",
+			set:     canary(true),
+			warning: denied,
+		},
+		{
+			name:        "canary permission denied",
+			set:         canary(false),
+			warning:     denied,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionTrue,
+		},
+		{
+			name:    "canary still permission denied",
+			set:     canary(false),
+			warning: denied,
+			active:  true,
+		},
+		{
+			name:        "canary pass after permission denied",
+			set:         canary(true),
+			warning:     denied,
+			active:      true,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionFalse,
+		},
+		{
+			name:    "route admitted",
+			set:     route(true),
+			warning: rejected,
+		},
+		{
+			name:        "route rejected",
+			set:         route(false),
+			warning:     rejected,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionTrue,
+		},
+		{
+			name:    "route still rejected",
+			set:     route(false),
+			warning: rejected,
+			active:  true,
+		},
+		{
+			name:        "route admitted after rejection",
+			set:         route(true),
+			warning:     rejected,
+			active:      true,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionFalse,
+		},
+		{
+			name:      "autoscaler within range",
+			set:       autoscaling(false, "querier"),
+			component: "querier",
+			warning:   atMax,
+		},
+		{
+			name:        "autoscaler at max",
+			set:         autoscaling(true, "querier"),
+			component:   "querier",
+			warning:     atMax,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionTrue,
+		},
+		{
+			name:      "autoscaler still at max",
+			set:       autoscaling(true, "querier"),
+			component: "querier",
+			warning:   atMax,
+			active:    true,
+		},
+		{
+			name:      "autoscaler of other component within range",
+			set:       autoscaling(false, "distributor"),
+			component: "querier",
+			warning:   atMax,
+			active:    true,
+		},
+		{
+			name:        "autoscaler within range after at max",
+			set:         autoscaling(false, "querier"),
+			component:   "querier",
+			warning:     atMax,
+			active:      true,
+			wantUpdate:  true,
+			wantWarning: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := []metav1.Condition{ready}
+			if tc.active {
+				conditions = append(conditions, tc.warning)
+			}
+
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+				Status: lokiv1.LokiStackStatus{
+					Conditions: conditions,
+					Warnings:   listedWarnings(tc.component, conditions...),
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				conditions := ConditionsMap(obj.(*lokiv1.LokiStack))
+
+				warning := conditions[string(lokiv1.ConditionWarning)]
+				require.Equal(t, tc.wantWarning, warning.Status)
+				require.Equal(t, tc.warning.Reason, warning.Reason)
+				require.Equal(t, tc.warning.Message, warning.Message)
+
+				// Warnings are informational only and leave the condition Ready untouched
+				require.Equal(t, metav1.ConditionTrue, conditions[string(lokiv1.ConditionReady)].Status)
+				return nil
+			}
+
+			err := tc.set(context.Background(), k, r)
+			require.NoError(t, err)
+
+			if tc.wantUpdate {
+				require.Equal(t, 1, sw.UpdateCallCount())
+			} else {
+				require.Zero(t, sw.UpdateCallCount())
+			}
+		})
+	}
+}
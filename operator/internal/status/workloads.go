@@ -0,0 +1,99 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ViaQ/logerr/v2/kverrors"
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/external/k8s"
+	"github.com/grafana/loki/operator/internal/manifests"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetReadyFromWorkloads derives the readiness of the LokiStack from the status of the Deployments
+// and StatefulSets it controls, i.e. those with a controller owner reference to the LokiStack,
+// instead of the pod phases. The condition Ready is set if every workload has as many ready
+// replicas as desired. Otherwise the condition Pending is set, listing the workloads not ready
+// yet with their ready and desired replicas, e.g. "my-stack-ingester 1/2". A LokiStack without
+// any workload yet is pending as well.
+func SetReadyFromWorkloads(ctx context.Context, k k8s.Client, req ctrl.Request) error {
+	var stack lokiv1.LokiStack
+	if err := k.Get(ctx, req.NamespacedName, &stack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return kverrors.Wrap(err, "failed to lookup LokiStack", "name", req.NamespacedName)
+	}
+
+	notReady, total, err := notReadyWorkloads(ctx, k, &stack)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 && len(notReady) == 0 {
+		return SetReadyCondition(ctx, k, req)
+	}
+
+	pending := metav1.Condition{
+		Type:    string(lokiv1.ConditionPending),
+		Message: keyedMessage(req.NamespacedName, lokiv1.ConditionPending, podsMessage(MessageKeyPending, MessageKeyPendingPods, notReady)),
+		Reason:  string(lokiv1.ReasonPendingComponents),
+	}
+
+	return updateCondition(ctx, k, req, pending, MutualExclusionPolicy)
+}
+
+// notReadyWorkloads returns the workloads controlled by the stack with less ready replicas than
+// desired, formatted as "name ready/desired", and the total number of controlled workloads.
+func notReadyWorkloads(ctx context.Context, k k8s.Client, stack *lokiv1.LokiStack) ([]string, int, error) {
+	opts := []client.ListOption{
+		client.MatchingLabels(manifests.StackLabels(stack.Name)),
+		client.InNamespace(stack.Namespace),
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := k.List(ctx, &deployments, opts...); err != nil {
+		return nil, 0, kverrors.Wrap(err, "failed to list LokiStack deployments", "name", stack.Name)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := k.List(ctx, &statefulSets, opts...); err != nil {
+		return nil, 0, kverrors.Wrap(err, "failed to list LokiStack statefulsets", "name", stack.Name)
+	}
+
+	var (
+		notReady []string
+		total    int
+	)
+	check := func(obj metav1.Object, desired *int32, ready int32) {
+		if !metav1.IsControlledBy(obj, stack) {
+			return
+		}
+		total++
+
+		want := int32(1)
+		if desired != nil {
+			want = *desired
+		}
+		if ready < want {
+			notReady = append(notReady, fmt.Sprintf("%s %d/%d", obj.GetName(), ready, want))
+		}
+	}
+
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		check(d, d.Spec.Replicas, d.Status.ReadyReplicas)
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		check(s, s.Spec.Replicas, s.Status.ReadyReplicas)
+	}
+
+	return notReady, total, nil
+}
@@ -0,0 +1,135 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetReadyFromWorkloads(t *testing.T) {
+	owner := metav1.OwnerReference{
+		APIVersion: lokiv1.GroupVersion.String(),
+		Kind:       "LokiStack",
+		Name:       "my-stack",
+		UID:        "stack-uid",
+		Controller: pointer.Bool(true),
+	}
+	foreign := owner
+	foreign.Name = "other-stack"
+	foreign.UID = "other-uid"
+
+	deployment := func(name string, ref metav1.OwnerReference, replicas *int32, ready int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-ns", OwnerReferences: []metav1.OwnerReference{ref}},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas},
+			Status:     appsv1.DeploymentStatus{Replicas: ready, ReadyReplicas: ready},
+		}
+	}
+	statefulSet := func(name string, ref metav1.OwnerReference, replicas *int32, ready int32) appsv1.StatefulSet {
+		return appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "some-ns", OwnerReferences: []metav1.OwnerReference{ref}},
+			Spec:       appsv1.StatefulSetSpec{Replicas: replicas},
+			Status:     appsv1.StatefulSetStatus{Replicas: ready, ReadyReplicas: ready},
+		}
+	}
+
+	table := []struct {
+		name         string
+		deployments  []appsv1.Deployment
+		statefulSets []appsv1.StatefulSet
+		wantType     lokiv1.LokiStackConditionType
+		wantMessage  string
+	}{
+		{
+			name: "all workloads ready",
+			deployments: []appsv1.Deployment{
+				deployment("my-stack-distributor", owner, pointer.Int32(2), 2),
+				deployment("my-stack-querier", owner, nil, 1),
+			},
+			statefulSets: []appsv1.StatefulSet{
+				statefulSet("my-stack-ingester", owner, pointer.Int32(3), 3),
+			},
+			wantType:    lokiv1.ConditionReady,
+			wantMessage: messageReady,
+		},
+		{
+			name: "some workloads not ready",
+			deployments: []appsv1.Deployment{
+				deployment("my-stack-distributor", owner, pointer.Int32(2), 2),
+				deployment("my-stack-querier", owner, pointer.Int32(2), 0),
+			},
+			statefulSets: []appsv1.StatefulSet{
+				statefulSet("my-stack-ingester", owner, pointer.Int32(3), 1),
+			},
+			wantType:    lokiv1.ConditionPending,
+			wantMessage: messagePending + ": my-stack-ingester 1/3, my-stack-querier 0/2",
+		},
+		{
+			name: "workloads of other owners ignored",
+			deployments: []appsv1.Deployment{
+				deployment("my-stack-distributor", owner, pointer.Int32(1), 1),
+				deployment("other-stack-distributor", foreign, pointer.Int32(1), 0),
+			},
+			wantType:    lokiv1.ConditionReady,
+			wantMessage: messageReady,
+		},
+		{
+			name:        "no workloads",
+			wantType:    lokiv1.ConditionPending,
+			wantMessage: messagePending,
+		},
+	}
+
+	for _, tc := range table {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := lokiv1.LokiStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+					UID:       "stack-uid",
+				},
+			}
+
+			r := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "my-stack",
+					Namespace: "some-ns",
+				},
+			}
+
+			k, sw := setupFakes(&s)
+			k.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				switch list.(type) {
+				case *appsv1.DeploymentList:
+					k.SetClientObjectList(list, &appsv1.DeploymentList{Items: tc.deployments})
+				case *appsv1.StatefulSetList:
+					k.SetClientObjectList(list, &appsv1.StatefulSetList{Items: tc.statefulSets})
+				}
+				return nil
+			}
+			sw.UpdateStub = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				obj.(*lokiv1.LokiStack).DeepCopyInto(&s)
+				return nil
+			}
+
+			err := SetReadyFromWorkloads(context.Background(), k, r)
+			require.NoError(t, err)
+			require.Equal(t, 1, sw.UpdateCallCount())
+
+			c := ConditionsMap(&s)[string(tc.wantType)]
+			require.Equal(t, metav1.ConditionTrue, c.Status)
+			require.Equal(t, tc.wantMessage, c.Message)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package version exposes build information of the operator binary.
+package version
+
+// Version is the operator version. It is set at build time via:
+// -ldflags "-X github.com/grafana/loki/operator/internal/version.Version=<version>"
+var Version = "unknown"
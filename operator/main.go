@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/ViaQ/logerr/v2/kverrors"
 	"github.com/ViaQ/logerr/v2/log"
@@ -25,6 +27,7 @@ import (
 	lokiv1beta1 "github.com/grafana/loki/operator/apis/loki/v1beta1"
 	lokictrl "github.com/grafana/loki/operator/controllers/loki"
 	"github.com/grafana/loki/operator/internal/metrics"
+	"github.com/grafana/loki/operator/internal/status"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -49,12 +52,84 @@ func init() {
 }
 
 func main() {
-	var configFile string
+	var (
+		configFile     string
+		recoveryWindow time.Duration
+
+		conflictMaxChanges int
+		conflictWindow     time.Duration
+
+		conditionTTL time.Duration
+
+		flappingMaxTransitions int
+		flappingWindow         time.Duration
+
+		degradedErrorThreshold int
+
+		issueSprawlThreshold int
+
+		ownerLabel string
+
+		statusOpts = status.DefaultOptions()
+	)
 	flag.StringVar(&configFile, "config", "",
 		"The controller will load its initial configuration from this file. "+
 			"Omit this flag to use the default configuration values. "+
 			"Command-line flags override configuration from this file.",
 	)
+	flag.DurationVar(&recoveryWindow, "recovery-window", 0,
+		"The duration a LokiStack stays Recovering after being degraded or failed before it becomes Ready again. "+
+			"Omit this flag to set LokiStacks Ready immediately.",
+	)
+	flag.IntVar(&conflictMaxChanges, "conflict-max-changes", 0,
+		"The number of foreign changes to a LokiStack tolerated within the conflict window before it is degraded "+
+			"for a possible conflicting controller. Omit this flag to disable the detection.",
+	)
+	flag.DurationVar(&conflictWindow, "conflict-window", time.Minute,
+		"The sliding time window in which foreign changes to a LokiStack are counted.",
+	)
+	flag.DurationVar(&conditionTTL, "condition-ttl", 0,
+		"The age from which on Warning and Degraded conditions not re-asserted are cleared. "+
+			"Omit this flag to never expire conditions.",
+	)
+	flag.IntVar(&flappingMaxTransitions, "flapping-max-transitions", 0,
+		"The number of LokiStack condition transitions tolerated within the flapping window before the condition "+
+			"Flapping is reported. Omit this flag to disable the detection.",
+	)
+	flag.DurationVar(&flappingWindow, "flapping-window", 10*time.Minute,
+		"The sliding time window in which LokiStack condition transitions are counted.",
+	)
+	flag.IntVar(&degradedErrorThreshold, "degraded-error-threshold", 0,
+		"The number of consecutive reconciliations failing with a degraded error from which on a LokiStack is degraded "+
+			"instead of pending. Omit this flag to degrade on the first error.",
+	)
+	flag.IntVar(&issueSprawlThreshold, "issue-sprawl-threshold", 0,
+		"The number of distinct Degraded and Failed conditions on a LokiStack and its tenants above which "+
+			"a Warning summarizes them. Omit this flag to disable the summary.",
+	)
+	flag.StringVar(&ownerLabel, "owner-label", "",
+		"The label key=value pair identifying the LokiStacks owned by this operator. "+
+			"Omit this flag to own all LokiStacks.",
+	)
+	flag.Float64Var(&statusOpts.WALPressure.Warning, "wal-pressure-warning", statusOpts.WALPressure.Warning,
+		"The write ahead log disk usage in percent from which on a LokiStack reports a warning.",
+	)
+	flag.Float64Var(&statusOpts.WALPressure.Degraded, "wal-pressure-degraded", statusOpts.WALPressure.Degraded,
+		"The write ahead log disk usage in percent from which on a LokiStack is degraded.",
+	)
+	flag.DurationVar(&statusOpts.CertExpiryWarningWindow, "cert-expiry-warning-window", statusOpts.CertExpiryWarningWindow,
+		"The duration before the expiry of a certificate from which on a LokiStack reports a warning.",
+	)
+	flag.DurationVar(&statusOpts.ClockSkew.Warning, "clock-skew-warning", statusOpts.ClockSkew.Warning,
+		"The clock skew among the LokiStack components from which on a LokiStack reports a warning.",
+	)
+	flag.DurationVar(&statusOpts.ClockSkew.Degraded, "clock-skew-degraded", statusOpts.ClockSkew.Degraded,
+		"The clock skew among the LokiStack components from which on a LokiStack is degraded.",
+	)
+	flag.IntVar(&statusOpts.QueryQueueDepthThreshold, "query-queue-depth-threshold", statusOpts.QueryQueueDepthThreshold,
+		"The query scheduler queue depth from which on a LokiStack reports a saturated query path. "+
+			"Set to zero to disable the check.",
+	)
 	flag.Parse()
 
 	logger := log.NewLogger("loki-operator")
@@ -94,12 +169,41 @@ func main() {
 		}
 	}
 
+	var owns status.OwnershipPredicate
+	if ownerLabel != "" {
+		key, value, ok := strings.Cut(ownerLabel, "=")
+		if !ok || key == "" {
+			logger.Error(kverrors.New("owner-label flag requires a key=value pair", "value", ownerLabel), "")
+			os.Exit(1)
+		}
+		owns = status.OwnedByLabel(key, value)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		logger.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	statusOpts.Disabled = ctrlCfg.Gates.DisableStatusUpdates
+	statusOpts.RequireSchemaChangeApproval = ctrlCfg.Gates.RequireSchemaChangeApproval
+	statusOpts.AuditConditionTransitions = ctrlCfg.Gates.AuditConditionTransitions
+	statusOpts.PatchStatus = ctrlCfg.Gates.PatchStatusUpdates
+	statusOpts.RecoveryWindow = recoveryWindow
+	statusOpts.ConflictDetection = status.ConflictDetection{
+		MaxChanges: conflictMaxChanges,
+		Window:     conflictWindow,
+	}
+	statusOpts.ConditionTTL = conditionTTL
+	statusOpts.FlappingDetection = status.FlappingDetection{
+		MaxTransitions: flappingMaxTransitions,
+		Window:         flappingWindow,
+	}
+	statusOpts.DegradedErrorThreshold = degradedErrorThreshold
+	statusOpts.IssueSprawlThreshold = issueSprawlThreshold
+	statusOpts.Owns = owns
+	status.Configure(statusOpts)
+
 	if err = (&lokictrl.LokiStackReconciler{
 		Client:       mgr.GetClient(),
 		Log:          logger.WithName("controllers").WithName("lokistack"),